@@ -23,6 +23,15 @@ type Symbols struct {
 	Untick       string
 }
 
+// Render() vrátí checkbox složený ze symbolů, zatržený pokud je ticked == true
+func (s Symbols) Render(ticked bool) string {
+	if ticked {
+		return s.LeftBracket + s.Tick + s.RightBracket
+	}
+
+	return s.LeftBracket + s.Untick + s.RightBracket
+}
+
 var (
 	// DefaultKeys je výchozí mapování klávesových zkratek
 	DefaultKeys = Keys{
@@ -139,14 +148,7 @@ func (m CheckboxModel) Update(msg tea.Msg) (CheckboxModel, tea.Msg) {
 // View() je standardní funkce pro bubbletea
 // Volat v hlavním modelu a výsledek spojit s ostatním výstupem
 func (m CheckboxModel) View() string {
-	var s string
-
-	if m.ticked {
-		s = m.checkboxStyle.Render(m.symbols.LeftBracket + m.symbols.Tick + m.symbols.RightBracket + " ")
-	} else {
-		s = m.checkboxStyle.Render(m.symbols.LeftBracket + m.symbols.Untick + m.symbols.RightBracket + " ")
-	}
-
+	s := m.checkboxStyle.Render(m.symbols.Render(m.ticked) + " ")
 	s += m.titleStyle.Render(m.title)
 
 	return s
@@ -1,11 +1,24 @@
 // Package qm slouží pro zobrazení potvrzovacího okna pro ukončení aplikace
 // Lze upravit vzhled (barvy a okraj okna) a klávesové zkratky
+// Výsledek potvrzení a zrušení lze změnit (WithOnConfirm(), WithConfirmMsg(), ...),
+// model pak lze použít i jako obecné potvrzovací okno
 
 package qm
 
 import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tomaspantlik/crapmodels/window"
 )
 
 var (
@@ -16,11 +29,12 @@ var (
 		Yes1:          "a",
 		No1:           "n",
 		No2:           tea.KeyEsc.String(),
-		Next1:         tea.KeyLeft.String(),
-		Next2:         tea.KeyRight.String(),
-		Next3:         "h",
-		Next4:         "l",
-		Next5:         tea.KeyTab.String(),
+		Prev1:         tea.KeyLeft.String(),
+		Prev2:         "h",
+		Prev3:         tea.KeyShiftTab.String(),
+		Next1:         tea.KeyRight.String(),
+		Next2:         "l",
+		Next3:         tea.KeyTab.String(),
 		SelectButton1: tea.KeyEnter.String(),
 		SelectButton2: " ",
 	}
@@ -35,6 +49,89 @@ var (
 	DefaultNo = "[n]e"
 )
 
+// ConfirmedMsg je zpráva pro potvrzení okna, viz WithConfirmMsg()
+// Typed je text zadaný pro potvrzení, viz WithTypedConfirmation()
+type ConfirmedMsg struct {
+	Typed string
+}
+
+// CancelledMsg je zpráva pro zrušení okna, vrací ji tea.Cmd z Update() po každém
+// zrušení okna, pokud není nastaveno jinak, viz WithOnCancel() a WithLegacyCancel()
+type CancelledMsg struct{}
+
+// ButtonPressedMsg je zpráva, kterou vrací tea.Cmd z Update() po stisku tlačítka
+// definovaného přes WithButtons(), pokud tlačítko nemá vlastní tea.Cmd
+// Typed je text zadaný pro potvrzení, viz WithTypedConfirmation()
+type ButtonPressedMsg struct {
+	Index int
+	Label string
+	Typed string
+}
+
+// CountdownAction je akce provedená po uplynutí odpočtu, viz WithCountdown()
+type CountdownAction int
+
+const (
+	// CountdownConfirm po uplynutí odpočtu okno potvrdí
+	CountdownConfirm CountdownAction = iota
+	// CountdownCancel po uplynutí odpočtu okno zruší
+	CountdownCancel
+)
+
+// CountdownKeyMode určuje, co se stane s odpočtem po stisku klávesy, viz
+// WithCountdownKeyMode()
+type CountdownKeyMode int
+
+const (
+	// CountdownKeyStop odpočet zastaví a zbývající čas se přestane zobrazovat
+	CountdownKeyStop CountdownKeyMode = iota
+	// CountdownKeyFreeze odpočet zastaví natrvalo, zbývající čas zůstane zobrazený
+	CountdownKeyFreeze
+	// CountdownKeyIgnore odpočet běží dál
+	CountdownKeyIgnore
+)
+
+// Result je výsledek posledního zavření okna, viz GetLastResult()
+type Result int
+
+const (
+	// Confirmed okno bylo potvrzeno (první tlačítko)
+	Confirmed Result = iota
+	// Cancelled okno bylo zrušeno (tlačítko ne, s WithButtons() poslední
+	// tlačítko, nebo klávesa Show)
+	Cancelled
+	// Pressed bylo stisknuto jiné tlačítko z WithButtons() než první a poslední,
+	// které tlačítko to bylo, vrátí GetLastButton()
+	Pressed
+)
+
+// Backdrop určuje, co se zobrazí za oknem, viz WithBackdrop()
+type Backdrop int
+
+const (
+	// BackdropBlank místo pozadí vyplní obrazovku mezerami s barvou
+	// WithWhiteSpaceColor()
+	BackdropBlank Backdrop = iota
+	// BackdropDim zobrazí pozadí s původními styly, přes které přidá styl
+	// WithBackdropStyle()
+	BackdropDim
+	// BackdropNone zobrazí pozadí beze změny
+	BackdropNone
+)
+
+// countdownTickMsg posouvá odpočet, viz WithCountdown()
+// id rozlišuje modely, tag rozlišuje jednotlivá zobrazení okna
+type countdownTickMsg struct {
+	id  int64
+	tag int
+}
+
+// lastID je poslední přidělené id modelu
+var lastID atomic.Int64
+
+// buttonGap je mezera mezi tlačítky
+const buttonGap = "    "
+
 // Keys je typ pro definování klávesových zkratek
 // Vychází z bubbletea.KeyMsg.String()
 // Každá akce může mít více klávesových zkratek (Show1, Show2, ...)
@@ -49,6 +146,9 @@ type Keys struct {
 	No1           string
 	No2           string
 	No3           string
+	Prev1         string
+	Prev2         string
+	Prev3         string
 	Next1         string
 	Next2         string
 	Next3         string
@@ -63,16 +163,45 @@ type Keys struct {
 // Pro interakci s modelem se používají výhradně receiver funkce, které vracejí
 // zpět upravený model
 type QuitModel struct {
+	id        int64
 	displayed bool
 
+	// selectedButton je pozice vybraného tlačítka v pořadí zobrazení, viz order
 	selectedButton uint
+	defaultButton  uint
+	order          []int
+	navWrap        bool
+
+	rememberSelection bool
+	hasResult         bool
+	lastButton        int
 
 	screenWidth, screenHeight int
+	maxWidth                  int
+	width, height             int
+	placeH, placeV            lipgloss.Position
 
 	keys          Keys
 	questionStr   string
 	yesStr, noStr string
 
+	onConfirm, onCancel tea.Cmd
+	legacyCancel        bool
+
+	buttons       []string
+	buttonKeys    map[int][]string
+	buttonCmds    map[int]tea.Cmd
+	buttonHotkeys []rune
+
+	countdown        time.Duration
+	countdownAction  CountdownAction
+	countdownKeyMode CountdownKeyMode
+	countdownLeft    time.Duration
+	countdownTag     int
+	countdownRunning bool
+	countdownShown   bool
+	pendingTick      bool
+
 	defaultStyle          lipgloss.Style
 	windowStyle           lipgloss.Style
 	borderType            lipgloss.Border
@@ -80,7 +209,19 @@ type QuitModel struct {
 	borderBg, borderFg    lipgloss.Color
 	unselectedButtonStyle lipgloss.Style
 	selectedButtonStyle   lipgloss.Style
+	hotkeyStyle           lipgloss.Style
+	buttonMinWidth        int
+	equalButtons          bool
 	whiteSpaceBg          lipgloss.Color
+	whiteSpaceChars       []rune
+	backdrop              Backdrop
+	backdropStyle         lipgloss.Style
+
+	passthroughFilter func(tea.Msg) bool
+
+	confirmWord         string
+	confirmInput        textinput.Model
+	disabledButtonStyle lipgloss.Style
 }
 
 // NewQuitModel() je funkce pro vytvoření nového QuitModelu
@@ -88,11 +229,17 @@ type QuitModel struct {
 // Pro nastavení vlastností modelu použít jako parametry funkce WithKeys a další
 func NewQuitModel(options ...func(*QuitModel)) QuitModel {
 	qm := QuitModel{
+		id:             lastID.Add(1),
 		selectedButton: 0,
 		keys:           DefaultKeys,
 		questionStr:    DefaultQuestion,
 		yesStr:         DefaultYes,
 		noStr:          DefaultNo,
+		onConfirm:      tea.Quit,
+		maxWidth:       60,
+		placeH:         lipgloss.Center,
+		placeV:         lipgloss.Center,
+		navWrap:        true,
 		windowStyle:    lipgloss.NewStyle().Bold(true),
 		borderStyle: lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
@@ -110,13 +257,39 @@ func NewQuitModel(options ...func(*QuitModel)) QuitModel {
 			Width(10).Align(lipgloss.Center).
 			Bold(true).
 			BorderStyle(lipgloss.RoundedBorder()),
-		whiteSpaceBg: lipgloss.Color("#000000"),
+		hotkeyStyle: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF0000")).
+			Underline(true),
+		buttonMinWidth: 10,
+		equalButtons:   true,
+		whiteSpaceBg:   lipgloss.Color("#000000"),
+		backdropStyle:  lipgloss.NewStyle().Faint(true),
+
+		passthroughFilter: DefaultPassthrough,
+		disabledButtonStyle: lipgloss.NewStyle().
+			Background(lipgloss.Color("#000000")).
+			Foreground(lipgloss.Color("#808080")).
+			Align(lipgloss.Center).
+			Bold(true).
+			BorderStyle(lipgloss.RoundedBorder()),
 	}
 
 	for _, opt := range options {
 		opt(&qm)
 	}
 
+	qm.confirmInput = textinput.New()
+	qm.confirmInput.Prompt = "> "
+	qm.confirmInput.Placeholder = qm.confirmWord
+	qm.confirmInput.Width = lipgloss.Width(qm.confirmWord) + 1
+	qm.confirmInput.TextStyle = qm.windowStyle
+	qm.confirmInput.PromptStyle = qm.windowStyle
+	qm.confirmInput.PlaceholderStyle = qm.windowStyle.Faint(true)
+	qm.confirmInput.Cursor.Style = qm.windowStyle
+	qm.confirmInput.Focus()
+
+	qm.selectedButton = qm.defaultPosition()
+
 	return qm
 }
 
@@ -145,6 +318,233 @@ func WithYesNoStr(yes, no string) func(*QuitModel) {
 	}
 }
 
+// WithOnConfirm() definuje tea.Cmd, který Update() vrátí po potvrzení okna
+// Pokud není použito, použije se tea.Quit
+// Pro nil se okno po potvrzení jen skryje
+func WithOnConfirm(cmd tea.Cmd) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.onConfirm = cmd
+	}
+}
+
+// WithOnCancel() definuje tea.Cmd, který Update() vrátí po zrušení okna
+// Pokud není použito (nebo je cmd nil), vrací se tea.Cmd se zprávou CancelledMsg
+func WithOnCancel(cmd tea.Cmd) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.onCancel = cmd
+	}
+}
+
+// WithConfirmMsg() nastaví, že po potvrzení okna Update() místo tea.Quit vrátí
+// tea.Cmd se zprávou msg (např. qm.ConfirmedMsg{})
+// Hlavní model tak může po zpracování zprávy nejdřív uklidit a tea.Quit vrátit
+// sám
+func WithConfirmMsg(msg tea.Msg) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.onConfirm = func() tea.Msg { return msg }
+	}
+}
+
+// WithLegacyCancel() zapne původní chování zrušení okna: bez WithOnCancel() se
+// nevrací žádný tea.Cmd a po výběru tlačítka ne klávesou SelectButton Update()
+// vrátí i stisknutou klávesu
+func WithLegacyCancel(legacy bool) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.legacyCancel = legacy
+	}
+}
+
+// WithCancelMsg() nastaví, že po zrušení okna Update() vrátí tea.Cmd se zprávou
+// msg (např. qm.CancelledMsg{})
+func WithCancelMsg(msg tea.Msg) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.onCancel = func() tea.Msg { return msg }
+	}
+}
+
+// WithButtons() nahradí tlačítka ano/ne vlastními tlačítky s texty labels
+// Stisk tlačítka okno skryje a vrátí tea.Cmd se zprávou ButtonPressedMsg, nebo
+// tea.Cmd nastavený přes WithButtonCmd()
+// Klávesy Yes a No a volby WithOnConfirm() a WithOnCancel() se pak nepoužívají,
+// klávesové zkratky tlačítek se nastavují přes WithButtonKeys()
+// Tlačítka, která se nevejdou vedle sebe, se zalomí na další řádek
+// Bez labels zůstanou tlačítka ano/ne
+func WithButtons(labels ...string) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.buttons = nil
+		if len(labels) > 0 {
+			qm.buttons = slices.Clone(labels)
+		}
+	}
+}
+
+// WithButtonKeys() definuje klávesové zkratky tlačítka index, viz WithButtons()
+// Klávesa stiskne tlačítko, i když není vybrané
+func WithButtonKeys(index int, keys ...string) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		if qm.buttonKeys == nil {
+			qm.buttonKeys = make(map[int][]string)
+		}
+		qm.buttonKeys[index] = slices.Clone(keys)
+	}
+}
+
+// WithButtonHotkeys() definuje klávesové zkratky tlačítek jako znaky, hotkeys[i]
+// patří tlačítku i, 0 znamená bez zkratky
+// První výskyt znaku v textu tlačítka (bez ohledu na velikost písmen) se zobrazí
+// ve stylu WithHotkeyStyle(), stisk znaku tlačítko stiskne, i když není vybrané
+// Zkratky platí vedle kláves Yes a No a WithButtonKeys()
+func WithButtonHotkeys(hotkeys ...rune) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.buttonHotkeys = slices.Clone(hotkeys)
+	}
+}
+
+// WithHotkeyStyle() definuje styl znaku zkratky v textu tlačítka, viz
+// WithButtonHotkeys()
+// Styl se doplní o barvy tlačítka, pokud není použito, znak je červený a podtržený
+func WithHotkeyStyle(style lipgloss.Style) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.hotkeyStyle = style
+	}
+}
+
+// WithButtonCmd() definuje tea.Cmd, který Update() vrátí po stisku tlačítka
+// index místo ButtonPressedMsg, viz WithButtons()
+func WithButtonCmd(index int, cmd tea.Cmd) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		if qm.buttonCmds == nil {
+			qm.buttonCmds = make(map[int]tea.Cmd)
+		}
+		qm.buttonCmds[index] = cmd
+	}
+}
+
+// WithMaxWidth() definuje maximální šířku obsahu okna (bez okraje)
+// Okno se přizpůsobí textu otázky a tlačítkům, delší otázka se zalomí po slovech,
+// okno je vždy alespoň o 4 znaky užší než obrazovka
+// Pokud není použito, je maximální šířka 60
+func WithMaxWidth(width int) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.maxWidth = width
+	}
+}
+
+// WithDefaultButton() definuje tlačítko vybrané po zobrazení okna (0 je první
+// tlačítko), pro nebezpečné akce je vhodné nastavit tlačítko ne (1)
+// Při každém zobrazení okna se výběr vrátí na toto tlačítko
+// Pokud není použito, je vybrané první tlačítko
+func WithDefaultButton(index uint) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.defaultButton = index
+	}
+}
+
+// WithButtonOrder() definuje pořadí zobrazení tlačítek, order jsou indexy
+// tlačítek zleva doprava (např. 1, 0 zobrazí ne vlevo a ano vpravo)
+// Navigace klávesami Next i výběr se řídí pořadím zobrazení, indexy tlačítek
+// (WithDefaultButton(), ButtonPressedMsg, ...) se nemění
+// Pokud order neobsahuje každé tlačítko právě jednou, použije se výchozí pořadí
+func WithButtonOrder(order ...int) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.order = slices.Clone(order)
+	}
+}
+
+// WithYesNoSwapped() zobrazí tlačítko ne vlevo a ano vpravo, viz WithButtonOrder()
+func WithYesNoSwapped(swapped bool) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		if swapped {
+			qm.order = []int{1, 0}
+		} else {
+			qm.order = nil
+		}
+	}
+}
+
+// WithNavWrap() nastaví, jestli navigace klávesami Prev a Next za posledním
+// tlačítkem pokračuje prvním a naopak (true), nebo se na krajích zastaví (false)
+// Pokud není použito, navigace pokračuje dokola
+func WithNavWrap(wrap bool) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.navWrap = wrap
+	}
+}
+
+// WithRememberSelection() nastaví, že se okno zobrazí s vybraným naposledy
+// stisknutým tlačítkem místo výchozího tlačítka (WithDefaultButton())
+// Dokud nebylo žádné tlačítko stisknuté (nebo po ResetResult()), vybere se výchozí
+func WithRememberSelection(remember bool) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.rememberSelection = remember
+	}
+}
+
+// WithCountdown() zapne odpočet, po jehož uplynutí okno samo provede akci action
+// Odpočet začne při každém zobrazení okna, zbývající sekundy se zobrazují za
+// otázkou, např. "Restartovat? (8s)"
+// S WithButtons() potvrzení stiskne první a zrušení poslední tlačítko
+// Pokud je potvrzení po uplynutí odpočtu neaktivní (WithTypedConfirmation()),
+// odpočet se zastaví a skryje a okno zůstane zobrazené, nic se nepotvrdí ani nezruší
+// Skrytím okna se odpočet zastaví, stisk klávesy viz WithCountdownKeyMode()
+func WithCountdown(d time.Duration, action CountdownAction) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.countdown = d
+		qm.countdownAction = action
+	}
+}
+
+// WithCountdownKeyMode() definuje, co se stane s odpočtem po stisku klávesy,
+// viz WithCountdown()
+// Pokud není použito, stisk klávesy odpočet zastaví (CountdownKeyStop)
+func WithCountdownKeyMode(mode CountdownKeyMode) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.countdownKeyMode = mode
+	}
+}
+
+// WithPlacement() definuje umístění okna na obrazovce, 0 je vlevo/nahoře, 1 je
+// vpravo/dole (např. lipgloss.Center, 0.3 umístí okno do horní třetiny)
+// Pokud není použito, je okno uprostřed obrazovky
+func WithPlacement(h, v lipgloss.Position) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.placeH, qm.placeV = h, v
+	}
+}
+
+// WithSize() definuje pevnou velikost okna včetně okraje, okno se pak nepřizpůsobuje
+// otázce ani obrazovce, obsah je svisle uprostřed, co se nevejde, se ořízne
+// 0 znamená automatickou šířku nebo výšku, viz WithMaxWidth()
+func WithSize(width, height int) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.width, qm.height = width, height
+	}
+}
+
+// WithTypedConfirmation() zapne potvrzení napsáním slova word (např. názvu
+// mazané položky), pod otázkou se zobrazí řádek pro zadání textu
+// Dokud zadaný text neodpovídá word, tlačítko potvrzení (první tlačítko) je
+// neaktivní a klávesy pro potvrzení nic nedělají, zrušení funguje vždy
+// Znaky se píšou do řádku (klávesové zkratky ze znaků se nepoužijí),
+// Backspace a ctrl+u mažou, text se smaže při každém zobrazení okna
+// Zadaný text je v ConfirmedMsg.Typed a ButtonPressedMsg.Typed
+func WithTypedConfirmation(word string) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.confirmWord = word
+	}
+}
+
+// WithDisabledButtonColors() definuje barvu popředí a pozadí neaktivního tlačítka,
+// viz WithTypedConfirmation()
+func WithDisabledButtonColors(fg, bg lipgloss.Color) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.disabledButtonStyle = qm.windowStyle.
+			Foreground(fg).Background(bg).
+			Align(lipgloss.Center).Bold(true).
+			BorderStyle(lipgloss.RoundedBorder())
+	}
+}
+
 // WithBorderType() definuje typ okraje (lipgloss.Border) okna
 // Pokud není použito, použije se lipgloss.RoundedBorder()
 func WithBorderType(borderStyle lipgloss.Border) func(*QuitModel) {
@@ -194,14 +594,88 @@ func WithSelectedButtonColors(fg, bg lipgloss.Color) func(*QuitModel) {
 	}
 }
 
+// WithButtonMinWidth() definuje minimální šířku tlačítek (bez okraje)
+// Tlačítka se přizpůsobí textu (s mezerou na každé straně), kratší texty se
+// zarovnají na střed minimální šířky
+// Pokud není použito, je minimální šířka 10
+func WithButtonMinWidth(width int) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.buttonMinWidth = width
+	}
+}
+
+// WithEqualButtonWidths() nastaví, jestli mají mít všechna tlačítka šířku podle
+// nejdelšího textu (true), nebo každé podle svého textu (false)
+// Pokud není použito, jsou všechna tlačítka stejně široká
+func WithEqualButtonWidths(equal bool) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.equalButtons = equal
+	}
+}
+
 // WithWhiteSpaceColor() definuje barvu pozadí za oknem
-// Používá se lipgloss.Place(.., lipgloss.WithWhitespaceBackground(bg))
+// Používá se s BackdropBlank, viz WithBackdrop() a WithWhiteSpaceChars()
 func WithWhiteSpaceColor(bg lipgloss.Color) func(*QuitModel) {
 	return func(qm *QuitModel) {
 		qm.whiteSpaceBg = bg
 	}
 }
 
+// WithWhiteSpaceChars() definuje znaky, kterými se vyplní prostor za oknem
+// s BackdropBlank (např. "░"), znaky se opakují dokola, barva pozadí je podle
+// WithWhiteSpaceColor()
+// Povolené jsou jen tisknutelné znaky široké jeden sloupec, jinak se volba ignoruje
+// Pokud není použito, prostor se vyplní mezerami
+func WithWhiteSpaceChars(chars string) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		runes := []rune(chars)
+		valid := !slices.ContainsFunc(runes, func(r rune) bool {
+			return !unicode.IsPrint(r) || ansi.StringWidth(string(r)) != 1
+		})
+		if valid {
+			qm.whiteSpaceChars = runes
+		}
+	}
+}
+
+// WithBackdrop() definuje, co se zobrazí za oknem
+// BackdropDim a BackdropNone vykreslí okno přes pozadí předané do View(), pozadí
+// se doplní nebo ořízne na velikost obrazovky
+// Pokud není použito, použije se BackdropBlank (jen okno na prázdné obrazovce)
+func WithBackdrop(backdrop Backdrop) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.backdrop = backdrop
+	}
+}
+
+// WithBackdropStyle() definuje styl pozadí pro BackdropDim, viz WithBackdrop()
+// Pokud není použito, pozadí je ztlumené (Faint)
+func WithBackdropStyle(style lipgloss.Style) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.backdropStyle = style
+	}
+}
+
+// WithPassthroughFilter() definuje, které zprávy Update() pošle dál, když je okno
+// zobrazené, filtr vrací true pro zprávy, které se mají poslat dál
+// Filtr se ptá jen na zprávy, které okno samo nezpracuje, nil propustí všechny
+// zprávy
+// Pokud není použito, použije se DefaultPassthrough()
+func WithPassthroughFilter(filter func(tea.Msg) bool) func(*QuitModel) {
+	return func(qm *QuitModel) {
+		qm.passthroughFilter = filter
+	}
+}
+
+// DefaultPassthrough() je výchozí filtr zpráv při zobrazeném okně, viz
+// WithPassthroughFilter()
+// Propustí všechny zprávy kromě bubbletea.KeyMsg
+func DefaultPassthrough(msg tea.Msg) bool {
+	_, ok := msg.(tea.KeyMsg)
+
+	return !ok
+}
+
 // Init() standardní definice Init() pro bubbletea
 func (m QuitModel) Init() tea.Cmd {
 	return nil
@@ -215,83 +689,508 @@ func (m QuitModel) Init() tea.Cmd {
 //	m.quit, cmd, msg = m.quit.Update(msg)
 //
 // Pokud je okno zobrazeno, model si přebere bubbletea.KeyMsg pro klávesové zkratky
-// a nepošle je dál. Pokud okno není zobrazeno, model je pošle zpátky
+// a nepošle je dál (ostatní zprávy podle WithPassthroughFilter()). Pokud okno není
+// zobrazeno, model je pošle zpátky
+// Klávesa Show okno zobrazí, pokud je okno zobrazené, zavře ho jako zrušení
+// (klávesy tlačítek mají přednost, výchozí Esc je i klávesa No)
+// Po potvrzení nebo zrušení se okno skryje a vrátí se tea.Cmd podle
+// WithOnConfirm() a WithOnCancel() (výchozí je tea.Quit a CancelledMsg)
+// Zrušení (klávesa No, Show i výběr tlačítka ne) vrací vždy nil bubbletea.Msg,
+// viz WithLegacyCancel()
+// S WithCountdown() vrací tea.Cmd pro odpočet, po zobrazení okna přes Display()
+// ho vrátí následující volání Update() (pro okamžité spuštění použít DisplayCmd())
 func (m QuitModel) Update(msg tea.Msg) (QuitModel, tea.Cmd, tea.Msg) {
+	m, cmd, msg := m.update(msg)
+
+	if m.pendingTick {
+		cmd = tea.Batch(cmd, m.countdownTick())
+		m.pendingTick = false
+	}
+
+	return m, cmd, msg
+}
+
+// update() zpracuje zprávu pro Update()
+func (m QuitModel) update(msg tea.Msg) (QuitModel, tea.Cmd, tea.Msg) {
 	switch msg := msg.(type) {
 
+	case countdownTickMsg:
+		if msg.id != m.id {
+			break
+		}
+		if !m.countdownRunning || msg.tag != m.countdownTag {
+			return m, nil, nil
+		}
+
+		m.countdownLeft -= time.Second
+		if m.countdownLeft > 0 {
+			return m, m.countdownTick(), nil
+		}
+
+		m, cmd := m.countdownExpired()
+		return m, cmd, nil
+
 	case tea.WindowSizeMsg:
 		m.screenHeight = msg.Height
 		m.screenWidth = msg.Width
 
-		return m, nil, msg
+		return m, nil, m.passthrough(msg)
 
 	case tea.KeyMsg:
 		if !m.displayed {
 			if msg.String() == m.keys.Show1 || msg.String() == m.keys.Show2 || msg.String() == m.keys.Show3 {
-				m.displayed = true
-				return m, nil, nil
+				return m.Display(), nil, nil
 			}
 			return m, nil, msg
 		}
 
+		switch m.countdownKeyMode {
+		case CountdownKeyStop:
+			m.countdownRunning = false
+			m.countdownShown = false
+		case CountdownKeyFreeze:
+			m.countdownRunning = false
+		}
+
+		if m.confirmWord != "" {
+			switch msg.Type {
+			case tea.KeyRunes, tea.KeySpace, tea.KeyBackspace, tea.KeyCtrlU:
+				var cmd tea.Cmd
+				m.confirmInput, cmd = m.confirmInput.Update(msg)
+				return m, cmd, nil
+			}
+		}
+
+		for i := range m.labels() {
+			if slices.Contains(m.hotkeys(i), msg.String()) {
+				m, cmd := m.press(i)
+				return m, cmd, nil
+			}
+		}
+
 		switch msg.String() {
 
-		case m.keys.Yes1, m.keys.Yes2, m.keys.Yes3:
-			return m, tea.Quit, nil
+		case m.keys.Show1, m.keys.Show2, m.keys.Show3:
+			m, cmd := m.dismiss()
+			return m, cmd, nil
 
-		case m.keys.No1, m.keys.No2, m.keys.No3:
-			m.displayed = false
-			return m, nil, nil
+		case m.keys.Prev1, m.keys.Prev2, m.keys.Prev3:
+			m = m.moveSelection(-1)
 
 		case m.keys.Next1, m.keys.Next2, m.keys.Next3, m.keys.Next4, m.keys.Next5:
-			if m.selectedButton == 0 {
-				m.selectedButton = 1
-			} else {
-				m.selectedButton = 0
-			}
+			m = m.moveSelection(1)
 
 		case m.keys.SelectButton1, m.keys.SelectButton2, m.keys.SelectButton3:
-			if m.selectedButton == 0 {
-				return m, tea.Quit, nil
-			} else {
-				m.displayed = false
-				return m, nil, msg
+			if m.buttons == nil && m.selected() == 1 {
+				m, cmd := m.cancel()
+				if m.legacyCancel {
+					return m, cmd, msg
+				}
+				return m, cmd, nil
 			}
+			m, cmd := m.press(m.selected())
+			return m, cmd, nil
 
 		default:
-			return m, nil, nil
+			return m, nil, m.passthrough(msg)
+		}
+
+		return m, nil, msg
+	}
+
+	return m, nil, m.passthrough(msg)
+}
+
+// passthrough() vrátí msg, pokud okno není zobrazené nebo ji propustí filtr
+// z WithPassthroughFilter(), jinak nil
+func (m QuitModel) passthrough(msg tea.Msg) tea.Msg {
+	if !m.displayed || m.passthroughFilter == nil || m.passthroughFilter(msg) {
+		return msg
+	}
+
+	return nil
+}
+
+// confirm() skryje okno a vrátí tea.Cmd pro potvrzení, viz WithOnConfirm()
+func (m QuitModel) confirm() (QuitModel, tea.Cmd) {
+	if m.disabled(0) {
+		return m, nil
+	}
+
+	m = m.setResult(0)
+
+	cmd := m.onConfirm
+	if m.confirmWord == "" || cmd == nil {
+		return m.hide(), cmd
+	}
+
+	typed := m.confirmInput.Value()
+
+	return m.hide(), func() tea.Msg {
+		msg := cmd()
+		if confirmed, ok := msg.(ConfirmedMsg); ok {
+			confirmed.Typed = typed
+			return confirmed
+		}
+		return msg
+	}
+}
+
+// disabled() vrátí true, pokud je tlačítko i neaktivní, viz WithTypedConfirmation()
+func (m QuitModel) disabled(i int) bool {
+	return i == 0 && m.confirmWord != "" && m.confirmInput.Value() != m.confirmWord
+}
+
+// cancel() skryje okno a vrátí tea.Cmd pro zrušení, viz WithOnCancel()
+func (m QuitModel) cancel() (QuitModel, tea.Cmd) {
+	return m.setResult(1).hide(), m.cancelCmd()
+}
+
+// cancelCmd() vrátí tea.Cmd pro zrušení okna, viz WithOnCancel() a
+// WithLegacyCancel()
+func (m QuitModel) cancelCmd() tea.Cmd {
+	if m.onCancel != nil || m.legacyCancel {
+		return m.onCancel
+	}
+
+	return func() tea.Msg { return CancelledMsg{} }
+}
+
+// setResult() uloží výsledek zavření okna tlačítkem button, -1 znamená zavření
+// bez tlačítka, viz GetLastResult()
+func (m QuitModel) setResult(button int) QuitModel {
+	m.hasResult = true
+	m.lastButton = button
+
+	return m
+}
+
+// dismiss() zavře okno jako zrušení, s WithButtons() ho skryje a vrátí tea.Cmd
+// se zprávou CancelledMsg (s WithLegacyCancel() nic)
+func (m QuitModel) dismiss() (QuitModel, tea.Cmd) {
+	if m.buttons == nil {
+		return m.cancel()
+	}
+
+	if m.legacyCancel {
+		return m.setResult(-1).hide(), nil
+	}
+
+	return m.setResult(-1).hide(), func() tea.Msg { return CancelledMsg{} }
+}
+
+// hide() skryje okno a zastaví odpočet
+func (m QuitModel) hide() QuitModel {
+	m.displayed = false
+	m.countdownRunning = false
+	m.countdownShown = false
+	m.pendingTick = false
+
+	return m
+}
+
+// countdownTick() vrátí tea.Cmd, který po sekundě pošle další countdownTickMsg
+func (m QuitModel) countdownTick() tea.Cmd {
+	id, tag := m.id, m.countdownTag
+
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return countdownTickMsg{id: id, tag: tag}
+	})
+}
+
+// countdownExpired() provede akci po uplynutí odpočtu, viz WithCountdown()
+// Pokud je tlačítko potvrzení neaktivní, odpočet zastaví a skryje a okno nechá
+// zobrazené
+func (m QuitModel) countdownExpired() (QuitModel, tea.Cmd) {
+	if m.countdownAction == CountdownConfirm {
+		if m.disabled(0) {
+			m.countdownRunning = false
+			m.countdownShown = false
+			return m, nil
 		}
+		return m.press(0)
+	}
+	if m.buttons == nil {
+		return m.cancel()
+	}
+
+	return m.press(len(m.buttons) - 1)
+}
+
+// question() vrátí text otázky, s odpočtem i se zbývajícím časem left
+func (m QuitModel) question(left time.Duration) string {
+	if !m.countdownShown {
+		return m.questionStr
 	}
 
-	return m, nil, msg
+	return fmt.Sprintf("%s (%ds)", m.questionStr, int(math.Ceil(left.Seconds())))
 }
 
-func (m QuitModel) viewButtons() string {
-	var yes, no = m.yesStr, m.noStr
-	if len(yes) > 10 {
-		yes = m.yesStr[:10]
+// press() stiskne tlačítko i
+// Bez WithButtons() je tlačítko 0 potvrzení a tlačítko 1 zrušení
+func (m QuitModel) press(i int) (QuitModel, tea.Cmd) {
+	if m.buttons == nil {
+		if i == 0 {
+			return m.confirm()
+		}
+		return m.cancel()
+	}
+
+	if m.disabled(i) {
+		return m, nil
 	}
-	if len(no) > 10 {
-		no = m.noStr[:10]
+
+	m = m.setResult(i).hide()
+	if cmd, ok := m.buttonCmds[i]; ok {
+		return m, cmd
 	}
 
-	var yesButton, noButton string
-	if m.selectedButton == 0 {
-		yesButton = m.selectedButtonStyle.Width(10).BorderBackground(m.borderBg).Render(yes)
-		noButton = m.unselectedButtonStyle.Width(10).BorderBackground(m.borderBg).Render(no)
+	label, typed := m.buttons[i], ""
+	if m.confirmWord != "" {
+		typed = m.confirmInput.Value()
+	}
+
+	return m, func() tea.Msg {
+		return ButtonPressedMsg{Index: i, Label: label, Typed: typed}
+	}
+}
+
+// labels() vrátí texty tlačítek, bez WithButtons() tlačítka ano a ne
+func (m QuitModel) labels() []string {
+	if m.buttons == nil {
+		return []string{m.yesStr, m.noStr}
+	}
+
+	return m.buttons
+}
+
+// buttonOrder() vrátí indexy tlačítek v pořadí zobrazení, viz WithButtonOrder()
+func (m QuitModel) buttonOrder() []int {
+	n := len(m.labels())
+	if n == 0 {
+		return nil
+	}
+
+	if len(m.order) == n {
+		sorted := slices.Sorted(slices.Values(m.order))
+		if sorted[0] == 0 && sorted[n-1] == n-1 && len(slices.Compact(sorted)) == n {
+			return m.order
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	return order
+}
+
+// selected() vrátí index vybraného tlačítka
+func (m QuitModel) selected() int {
+	order := m.buttonOrder()
+
+	return order[min(int(m.selectedButton), len(order)-1)]
+}
+
+// moveSelection() posune výběr o step tlačítek v pořadí zobrazení, viz
+// WithNavWrap()
+func (m QuitModel) moveSelection(step int) QuitModel {
+	n := len(m.labels())
+	if n == 0 {
+		return m
+	}
+
+	pos := int(m.selectedButton) + step
+	if m.navWrap {
+		pos = (pos%n + n) % n
 	} else {
-		yesButton = m.unselectedButtonStyle.Width(10).BorderBackground(m.borderBg).Render(yes)
-		noButton = m.selectedButtonStyle.Width(10).BorderBackground(m.borderBg).Render(no)
+		pos = max(min(pos, n-1), 0)
 	}
+	m.selectedButton = uint(pos)
 
-	s := lipgloss.JoinHorizontal(
-		lipgloss.Center,
-		yesButton,
-		m.windowStyle.Height(3).Render("    "),
-		noButton,
-	)
+	return m
+}
 
-	return m.windowStyle.Width(40).Align(lipgloss.Center).Render(s)
+// defaultPosition() vrátí pozici výchozího tlačítka v pořadí zobrazení, viz
+// WithDefaultButton() a WithRememberSelection()
+func (m QuitModel) defaultPosition() uint {
+	order := m.buttonOrder()
+	button := int(min(m.defaultButton, uint(len(order)-1)))
+	if m.rememberSelection && m.hasResult && m.lastButton >= 0 && m.lastButton < len(order) {
+		button = m.lastButton
+	}
+
+	return uint(slices.Index(order, button))
+}
+
+// hotkeys() vrátí klávesové zkratky tlačítka i (bez prázdných)
+func (m QuitModel) hotkeys(i int) []string {
+	var keys []string
+	switch {
+	case m.buttons != nil:
+		keys = m.buttonKeys[i]
+	case i == 0:
+		keys = []string{m.keys.Yes1, m.keys.Yes2, m.keys.Yes3}
+	default:
+		keys = []string{m.keys.No1, m.keys.No2, m.keys.No3}
+	}
+
+	if hotkey := m.hotkey(i); hotkey != 0 {
+		keys = append(slices.Clone(keys), string(hotkey))
+	}
+
+	return slices.DeleteFunc(slices.Clone(keys), func(k string) bool { return k == "" })
+}
+
+// hotkey() vrátí znak zkratky tlačítka i, 0 pokud ho nemá, viz WithButtonHotkeys()
+func (m QuitModel) hotkey(i int) rune {
+	if i < len(m.buttonHotkeys) {
+		return m.buttonHotkeys[i]
+	}
+
+	return 0
+}
+
+// viewLabel() vrátí text tlačítka i se zvýrazněným znakem zkratky, style je
+// styl tlačítka
+func (m QuitModel) viewLabel(i int, label string, style lipgloss.Style) string {
+	hotkey := m.hotkey(i)
+	if hotkey == 0 {
+		return label
+	}
+
+	runes := []rune(label)
+	pos := slices.IndexFunc(runes, func(r rune) bool {
+		return unicode.ToLower(r) == unicode.ToLower(hotkey)
+	})
+	if pos < 0 {
+		return label
+	}
+
+	text := style.UnsetWidth().UnsetAlign().UnsetBorderStyle().UnsetBorderBackground()
+
+	return text.Render(string(runes[:pos])) +
+		m.hotkeyStyle.Inherit(text).Render(string(runes[pos])) +
+		text.Render(string(runes[pos+1:]))
+}
+
+// viewButton() vykreslí tlačítko i s textem label
+func (m QuitModel) viewButton(i int, label string) string {
+	width := m.buttonWidth(i)
+	label = ansi.Truncate(label, width, "…")
+
+	style := m.unselectedButtonStyle
+	switch {
+	case m.disabled(i) && i == m.selected():
+		style = m.disabledButtonStyle.Underline(true)
+	case m.disabled(i):
+		style = m.disabledButtonStyle
+	case i == m.selected():
+		style = m.selectedButtonStyle
+	}
+
+	if styled := m.viewLabel(i, label, style); styled != label {
+		// podtržení by lipgloss vykreslil po znacích včetně escape sekvencí
+		// zvýraznění, text tlačítka je už podtržený z viewLabel()
+		return style.UnsetUnderline().Width(width).BorderBackground(m.borderBg).Render(styled)
+	}
+
+	return style.Width(width).BorderBackground(m.borderBg).Render(label)
+}
+
+// buttonWidth() vrátí šířku tlačítka i bez okraje podle textu tlačítka (nebo
+// nejdelšího textu, viz WithEqualButtonWidths()) a minimální šířky, tlačítko
+// s okrajem se vždy vejde do okna
+func (m QuitModel) buttonWidth(i int) int {
+	labels := m.labels()
+
+	width := lipgloss.Width(labels[i])
+	if m.equalButtons {
+		for _, label := range labels {
+			width = max(width, lipgloss.Width(label))
+		}
+	}
+
+	return max(min(max(width+2, m.buttonMinWidth), m.widthLimit()-2), 1)
+}
+
+// buttonsWidth() vrátí šířku všech tlačítek v jednom řádku
+func (m QuitModel) buttonsWidth() int {
+	width := 0
+	for i, label := range m.labels() {
+		if i > 0 {
+			width += len(buttonGap)
+		}
+		width += lipgloss.Width(m.viewButton(i, label))
+	}
+
+	return width
+}
+
+// dialogWidth() vrátí šířku obsahu okna podle otázky a tlačítek v jednom řádku,
+// nejvýš maxWidth a o 4 znaky méně než šířka obrazovky, viz WithMaxWidth()
+func (m QuitModel) dialogWidth() int {
+	if m.width > 0 {
+		return m.widthLimit()
+	}
+
+	width := max(lipgloss.Width(m.question(m.countdown)), m.buttonsWidth())
+	if m.confirmWord != "" {
+		width = max(width, lipgloss.Width(m.confirmInput.Prompt)+m.confirmInput.Width+1)
+	}
+	width += 4
+
+	return min(width, m.widthLimit())
+}
+
+// widthLimit() vrátí maximální šířku obsahu okna podle maxWidth a šířky obrazovky,
+// s WithSize() šířku obsahu okna
+func (m QuitModel) widthLimit() int {
+	if m.width > 0 {
+		return max(m.width-2, 1)
+	}
+
+	limit := math.MaxInt
+	if m.maxWidth > 0 {
+		limit = m.maxWidth
+	}
+	if m.screenWidth > 0 {
+		limit = min(limit, m.screenWidth-4)
+	}
+
+	return max(limit, 5)
+}
+
+// viewButtons() vykreslí tlačítka vedle sebe, tlačítka, která se nevejdou do
+// šířky width, zalomí na další řádky
+func (m QuitModel) viewButtons(width int) string {
+	var rows, row []string
+	rowWidth := 0
+
+	flush := func() {
+		s := lipgloss.JoinHorizontal(lipgloss.Center, row...)
+		rows = append(rows, m.windowStyle.Width(width).Align(lipgloss.Center).Render(s))
+		row, rowWidth = nil, 0
+	}
+
+	labels := m.labels()
+	for _, i := range m.buttonOrder() {
+		button := m.viewButton(i, labels[i])
+		w := lipgloss.Width(button)
+		if len(row) > 0 && rowWidth+len(buttonGap)+w > width {
+			flush()
+		}
+		if len(row) > 0 {
+			row = append(row, m.windowStyle.Height(3).Render(buttonGap))
+			rowWidth += len(buttonGap)
+		}
+		row = append(row, button)
+		rowWidth += w
+	}
+	flush()
+
+	return lipgloss.JoinVertical(lipgloss.Center, rows...)
 }
 
 // View() je standardní funkce pro bubbletea, rozšířená o parametr background
@@ -304,30 +1203,211 @@ func (m QuitModel) viewButtons() string {
 //		   return s
 //	  }
 //
-// Pokud je okno zobrazeno, vrátí funkce výstup jen s oknem, jinak vrátí background
+// Pokud je okno zobrazeno, vrátí funkce výstup s oknem přes pozadí podle
+// WithBackdrop() (výchozí je jen okno), jinak vrátí background
 func (m QuitModel) View(background string) string {
-	if m.displayed {
+	if !m.displayed {
+		return background
+	}
+
+	dialog := m.viewDialog()
+	if m.backdrop == BackdropBlank && (m.screenWidth <= 0 || m.screenHeight <= 0) {
+		return dialog
+	}
+
+	bg := m.viewBackdrop(background)
+	x, y := window.Place(lipgloss.Width(bg), lipgloss.Height(bg), lipgloss.Width(dialog), lipgloss.Height(dialog), m.placeH, m.placeV)
+
+	return window.Overlay(bg, dialog, x, y)
+}
+
+// viewDialog() vykreslí okno s otázkou a tlačítky
+func (m QuitModel) viewDialog() string {
+	width := m.dialogWidth()
+	buttons := m.viewButtons(width)
+	q := m.windowStyle.Padding(1, 2).Width(width).Align(lipgloss.Center).Render(m.question(m.countdownLeft))
+	sp := m.windowStyle.Width(width).Render(" ")
+
+	s := lipgloss.JoinVertical(lipgloss.Center, q, buttons, sp)
+	if m.confirmWord != "" {
+		input := m.windowStyle.Width(width).Align(lipgloss.Center).Render(m.confirmInput.View())
+		s = lipgloss.JoinVertical(lipgloss.Center, q, input, sp, buttons, sp)
+	}
+	if m.height > 0 {
+		height := max(m.height-2, 1)
+		s = m.windowStyle.Width(width).Height(height).MaxHeight(height).
+			AlignVertical(lipgloss.Center).Render(s)
+	}
+
+	return m.borderStyle.Render(s)
+}
+
+// viewBackdrop() vrátí pozadí za oknem velikosti obrazovky (pokud není známá,
+// velikosti background) podle WithBackdrop()
+func (m QuitModel) viewBackdrop(background string) string {
+	lines := strings.Split(background, "\n")
+	if m.backdrop == BackdropBlank {
+		lines = nil
+	}
+
+	width, height := m.screenWidth, m.screenHeight
+	if width <= 0 {
+		width = lipgloss.Width(background)
+	}
+	if height <= 0 {
+		height = len(lines)
+	}
+
+	blank := lipgloss.NewStyle().Background(m.whiteSpaceBg)
+
+	out := make([]string, height)
+	for n := range out {
+		var line string
+		if n < len(lines) {
+			line = ansi.Truncate(lines[n], width, "")
+		}
+		line += strings.Repeat(" ", max(width-lipgloss.Width(line), 0))
+
+		switch m.backdrop {
+		case BackdropBlank:
+			line = blank.Render(m.whiteSpace(width))
+		case BackdropDim:
+			line = restyle(line, m.backdropStyle)
+		}
+		out[n] = line
+	}
 
-		buttons := m.viewButtons()
-		q := m.windowStyle.Padding(1, 2).Width(40).Align(lipgloss.Center).Render(m.questionStr)
-		sp := m.windowStyle.Width(40).Render(" ")
+	return strings.Join(out, "\n")
+}
+
+// restyle() přidá styl style na začátek line a za každou SGR sekvenci v line,
+// takže style platí i po změnách a resetech původních stylů
+// Z style se použijí jen atributy textu (barvy, Faint, ...), ne rozměry a okraje
+func restyle(line string, style lipgloss.Style) string {
+	sgr, _, _ := strings.Cut(style.Inline(true).UnsetPadding().UnsetWidth().Render("x"), "x")
+	if sgr == "" {
+		return line
+	}
+
+	var b strings.Builder
+	b.WriteString(sgr)
+
+	var state byte
+	for len(line) > 0 {
+		seq, _, n, newState := ansi.DecodeSequence(line, state, nil)
+		b.WriteString(seq)
+		if ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m") {
+			b.WriteString(sgr)
+		}
+		state, line = newState, line[n:]
+	}
+	b.WriteString(ansi.ResetStyle)
+
+	return b.String()
+}
 
-		s := lipgloss.JoinVertical(lipgloss.Center, q, buttons, sp)
-		s = m.borderStyle.Render(s)
+// whiteSpace() vrátí width znaků pro vyplnění prostoru za oknem, viz
+// WithWhiteSpaceChars()
+func (m QuitModel) whiteSpace(width int) string {
+	if len(m.whiteSpaceChars) == 0 {
+		return strings.Repeat(" ", width)
+	}
 
-		s = lipgloss.Place(
-			m.screenWidth, m.screenHeight, lipgloss.Center, lipgloss.Center, s,
-			lipgloss.WithWhitespaceBackground(m.whiteSpaceBg),
-		)
-		return s
+	var b strings.Builder
+	for n := range width {
+		b.WriteRune(m.whiteSpaceChars[n%len(m.whiteSpaceChars)])
 	}
 
-	return background
+	return b.String()
 }
 
-// Display() funkce zobrazí okno
+// Display() funkce zobrazí okno a vybere výchozí tlačítko, viz WithDefaultButton()
+// S WithCountdown() spustí odpočet, tea.Cmd pro něj vrátí následující Update(),
+// viz DisplayCmd()
 func (m QuitModel) Display() QuitModel {
 	m.displayed = true
+	m.selectedButton = m.defaultPosition()
+	m.confirmInput.Reset()
+
+	if m.countdown > 0 {
+		m.countdownTag++
+		m.countdownLeft = m.countdown
+		m.countdownRunning = true
+		m.countdownShown = true
+		m.pendingTick = true
+	}
+
+	return m
+}
+
+// DisplayCmd() zobrazí okno stejně jako Display() a vrátí tea.Cmd pro odpočet,
+// viz WithCountdown()
+// Na rozdíl od Display() odpočet běží hned, i když další zprávou pro Update()
+// bude stisk klávesy
+// Vrací QuitModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m QuitModel) DisplayCmd() (QuitModel, tea.Cmd) {
+	m = m.Display()
+	if !m.pendingTick {
+		return m, nil
+	}
+	m.pendingTick = false
+
+	return m, m.countdownTick()
+}
+
+// SetDefaultButton() nastaví tlačítko vybrané po zobrazení okna, viz
+// WithDefaultButton()
+// Vrací QuitModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m QuitModel) SetDefaultButton(index uint) QuitModel {
+	m.defaultButton = index
+
+	return m
+}
+
+// Hide() skryje okno a zastaví odpočet, nic nepotvrdí ani nezruší (nevrací tea.Cmd
+// z WithOnConfirm() ani WithOnCancel())
+// Vrací QuitModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m QuitModel) Hide() QuitModel {
+	return m.hide()
+}
+
+// IsDisplayed() vrátí true, pokud je okno zobrazeno
+func (m QuitModel) IsDisplayed() bool {
+	return m.displayed
+}
+
+// GetLastResult() vrátí výsledek posledního zavření okna, false pokud okno ještě
+// nebylo zavřeno tlačítkem ani klávesou Show (Hide() výsledek nemění)
+// Pro rozlišení tlačítek z WithButtons() viz GetLastButton()
+func (m QuitModel) GetLastResult() (Result, bool) {
+	switch {
+	case !m.hasResult:
+		return Cancelled, false
+	case m.lastButton == 0:
+		return Confirmed, true
+	case m.lastButton < 0 || m.lastButton >= len(m.labels())-1:
+		return Cancelled, true
+	default:
+		return Pressed, true
+	}
+}
+
+// GetLastButton() vrátí index naposledy stisknutého tlačítka, false pokud žádné
+// tlačítko nebylo stisknuto nebo bylo okno naposledy zavřeno klávesou Show
+func (m QuitModel) GetLastButton() (int, bool) {
+	if !m.hasResult || m.lastButton < 0 {
+		return -1, false
+	}
+
+	return m.lastButton, true
+}
+
+// ResetResult() zapomene výsledek posledního zavření okna, viz GetLastResult()
+// a WithRememberSelection()
+// Vrací QuitModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m QuitModel) ResetResult() QuitModel {
+	m.hasResult = false
+	m.lastButton = 0
 
 	return m
 }
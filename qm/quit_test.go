@@ -0,0 +1,416 @@
+package qm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/timer"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	}
+
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestEmptyButtons(t *testing.T) {
+	m := NewQuitModel(WithButtons(), WithButtonOrder(1, 0)).Display()
+
+	for _, k := range []string{"right", "left", "enter"} {
+		m, _, _ = m.Update(key(k))
+		_ = m.View("")
+	}
+
+	if got := m.labels(); len(got) != 2 {
+		t.Fatalf("tlačítka = %q, chci výchozí ano/ne", got)
+	}
+}
+
+func TestMoveSelection(t *testing.T) {
+	tests := []struct {
+		name string
+		wrap bool
+		step int
+		want uint
+	}{
+		{"vpravo s přetečením", true, 3, 0},
+		{"vlevo s přetečením", true, -1, 2},
+		{"vpravo bez přetečení", false, 5, 2},
+		{"vlevo bez přetečení", false, -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewQuitModel(WithButtons("a", "b", "c"), WithNavWrap(tt.wrap))
+			if got := m.moveSelection(tt.step).selectedButton; got != tt.want {
+				t.Fatalf("selectedButton = %d, chci %d", got, tt.want)
+			}
+		})
+	}
+
+	var empty QuitModel
+	empty.buttons = []string{}
+	if got := empty.moveSelection(1).selectedButton; got != 0 {
+		t.Fatalf("selectedButton bez tlačítek = %d, chci 0", got)
+	}
+}
+
+func TestDisplayCmdCountdown(t *testing.T) {
+	m := NewQuitModel(WithCountdown(2*time.Second, CountdownCancel))
+
+	m, cmd := m.DisplayCmd()
+	if cmd == nil {
+		t.Fatal("DisplayCmd() nevrátilo tea.Cmd pro odpočet")
+	}
+
+	tick := countdownTickMsg{id: m.id, tag: m.countdownTag}
+	m, cmd, _ = m.Update(tick)
+	if !m.countdownRunning || cmd == nil {
+		t.Fatal("odpočet po prvním ticku neběží")
+	}
+
+	m, cmd, _ = m.Update(tick)
+	if m.IsDisplayed() || cmd == nil {
+		t.Fatal("okno se po uplynutí odpočtu nezrušilo")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Fatal("po uplynutí odpočtu nepřišla CancelledMsg")
+	}
+}
+
+func TestCountdownKeyFreeze(t *testing.T) {
+	m := NewQuitModel(
+		WithCountdown(5*time.Second, CountdownConfirm),
+		WithCountdownKeyMode(CountdownKeyFreeze),
+	)
+
+	m, _ = m.DisplayCmd()
+	m, _, _ = m.Update(key("right"))
+	if m.countdownRunning || !m.countdownShown {
+		t.Fatal("odpočet se po klávese nezastavil se zobrazeným časem")
+	}
+
+	m, cmd, _ := m.Update(countdownTickMsg{id: m.id, tag: m.countdownTag})
+	if cmd != nil || !m.IsDisplayed() {
+		t.Fatal("zastavený odpočet pokračuje")
+	}
+}
+
+func TestBackdropDimKeepsStyles(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	background := red.Render("červené") + " normální"
+
+	m := NewQuitModel(WithBackdrop(BackdropDim), WithBackdropStyle(lipgloss.NewStyle().Faint(true)))
+	got := m.viewBackdrop(background)
+
+	if ansi.Strip(got) != ansi.Strip(background) {
+		t.Fatalf("text pozadí = %q, chci %q", ansi.Strip(got), ansi.Strip(background))
+	}
+	if !strings.Contains(got, "\x1b[31m") {
+		t.Fatalf("pozadí %q ztratilo původní barvu", got)
+	}
+	if n := strings.Count(got, "\x1b[2m"); n != 3 {
+		t.Fatalf("pozadí %q má Faint %d×, chci na začátku a po každé SGR sekvenci", got, n)
+	}
+}
+
+func TestLastResult(t *testing.T) {
+	tests := []struct {
+		key    string
+		result Result
+		button int
+	}{
+		{"1", Confirmed, 0},
+		{"2", Pressed, 1},
+		{"3", Cancelled, 2},
+		{"q", Cancelled, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			m := NewQuitModel(
+				WithButtons("Uložit", "Zahodit", "Zpět"),
+				WithButtonKeys(0, "1"), WithButtonKeys(1, "2"), WithButtonKeys(2, "3"),
+			)
+			if _, ok := m.GetLastResult(); ok {
+				t.Fatal("výsledek před prvním zavřením")
+			}
+
+			m, _, _ = m.Update(key("q"))
+			m, _, _ = m.Update(key(tt.key))
+
+			if got, ok := m.GetLastResult(); !ok || got != tt.result {
+				t.Fatalf("GetLastResult() = %v, %v, chci %v", got, ok, tt.result)
+			}
+			button, ok := m.GetLastButton()
+			if button != tt.button || ok != (tt.button >= 0) {
+				t.Fatalf("GetLastButton() = %d, %v, chci %d", button, ok, tt.button)
+			}
+		})
+	}
+}
+
+func TestDefaultButton(t *testing.T) {
+	tests := []struct {
+		name   string
+		button uint
+		order  []int
+		want   Result
+	}{
+		{"ano", 0, nil, Confirmed},
+		{"ne", 1, nil, Cancelled},
+		{"ano, prohozené pořadí", 0, []int{1, 0}, Confirmed},
+		{"ne, prohozené pořadí", 1, []int{1, 0}, Cancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewQuitModel(WithDefaultButton(tt.button), WithButtonOrder(tt.order...))
+			if got := m.selected(); got != int(tt.button) {
+				t.Fatalf("po vytvoření je vybrané tlačítko %d, chci %d", got, tt.button)
+			}
+
+			// po posunu výběru a opětovném zobrazení je vybrané zase výchozí tlačítko
+			m, _, _ = m.Update(key("q"))
+			m, _, _ = m.Update(key("right"))
+			m = m.Hide().Display()
+			if got := m.selected(); got != int(tt.button) {
+				t.Fatalf("po zobrazení je vybrané tlačítko %d, chci %d", got, tt.button)
+			}
+
+			m, cmd, _ := m.Update(key("enter"))
+			if got, _ := m.GetLastResult(); got != tt.want {
+				t.Fatalf("Enter na výchozím tlačítku: výsledek %v, chci %v", got, tt.want)
+			}
+			if cmd == nil {
+				t.Fatal("Enter na výchozím tlačítku nevrátil tea.Cmd")
+			}
+			switch cmd().(type) {
+			case tea.QuitMsg:
+				if tt.want != Confirmed {
+					t.Fatal("zrušení vrátilo tea.Quit")
+				}
+			case CancelledMsg:
+				if tt.want != Cancelled {
+					t.Fatal("potvrzení vrátilo CancelledMsg")
+				}
+			default:
+				t.Fatal("neočekávaná zpráva z tea.Cmd")
+			}
+		})
+	}
+
+	m := NewQuitModel().SetDefaultButton(1).Display()
+	if got := m.selected(); got != 1 {
+		t.Fatalf("SetDefaultButton(1): vybrané tlačítko %d", got)
+	}
+}
+
+func TestShowKey(t *testing.T) {
+	for _, show := range []string{"q", "ctrl+q"} {
+		t.Run(show, func(t *testing.T) {
+			keys := DefaultKeys
+			keys.Show1 = show
+			m := NewQuitModel(WithKeys(keys))
+
+			press := func(s string) (tea.Cmd, tea.Msg) {
+				t.Helper()
+				k := key(s)
+				if s == "ctrl+q" {
+					k = tea.KeyMsg{Type: tea.KeyCtrlQ}
+				}
+				var (
+					cmd tea.Cmd
+					msg tea.Msg
+				)
+				m, cmd, msg = m.Update(k)
+				return cmd, msg
+			}
+
+			if _, msg := press("x"); msg == nil || m.IsDisplayed() {
+				t.Fatal("skryté okno nepropustilo jinou klávesu")
+			}
+
+			cmd, msg := press(show)
+			if !m.IsDisplayed() || cmd != nil || msg != nil {
+				t.Fatal("klávesa Show okno nezobrazila nebo ji propustila")
+			}
+
+			if _, msg := press("x"); msg != nil || !m.IsDisplayed() {
+				t.Fatal("zobrazené okno propustilo jinou klávesu nebo se zavřelo")
+			}
+
+			cmd, msg = press(show)
+			if m.IsDisplayed() || msg != nil {
+				t.Fatal("klávesa Show zobrazené okno nezavřela")
+			}
+			if cmd == nil {
+				t.Fatal("zavření klávesou Show nevrátilo tea.Cmd")
+			}
+			if _, ok := cmd().(CancelledMsg); !ok {
+				t.Fatal("zavření klávesou Show nevrátilo CancelledMsg")
+			}
+			if got, _ := m.GetLastResult(); got != Cancelled {
+				t.Fatalf("výsledek %v, chci Cancelled", got)
+			}
+		})
+	}
+}
+
+func TestShowKeyWithButtons(t *testing.T) {
+	m := NewQuitModel(WithButtons("Uložit", "Zahodit", "Zpět"))
+
+	m, _, _ = m.Update(key("q"))
+	m, cmd, _ := m.Update(key("q"))
+	if m.IsDisplayed() || cmd == nil {
+		t.Fatal("klávesa Show zobrazené okno nezavřela")
+	}
+	if _, ok := cmd().(CancelledMsg); !ok {
+		t.Fatal("zavření klávesou Show nevrátilo CancelledMsg")
+	}
+	if button, ok := m.GetLastButton(); ok || button != -1 {
+		t.Fatalf("GetLastButton() = %d, %v, chci -1, false", button, ok)
+	}
+}
+
+func TestPassthroughFilter(t *testing.T) {
+	tick := timer.TickMsg{ID: 1}
+	ctrlC := tea.KeyMsg{Type: tea.KeyCtrlC}
+
+	// propustí jen ctrl+c, ostatní zprávy při zobrazeném okně zahodí
+	filter := func(msg tea.Msg) bool {
+		k, ok := msg.(tea.KeyMsg)
+		return ok && k.Type == tea.KeyCtrlC
+	}
+
+	tests := []struct {
+		name      string
+		options   []func(*QuitModel)
+		tick, key bool // propustí tick časovače, propustí ctrl+c
+	}{
+		{"výchozí filtr", nil, true, false},
+		{"vlastní filtr", []func(*QuitModel){WithPassthroughFilter(filter)}, false, true},
+		{"bez filtru", []func(*QuitModel){WithPassthroughFilter(nil)}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewQuitModel(tt.options...)
+
+			// skryté okno propustí vše
+			if _, _, msg := m.Update(tick); msg == nil {
+				t.Fatal("skryté okno nepropustilo tick časovače")
+			}
+			if _, _, msg := m.Update(ctrlC); msg == nil {
+				t.Fatal("skryté okno nepropustilo ctrl+c")
+			}
+
+			m = m.Display()
+			if _, _, msg := m.Update(tick); (msg != nil) != tt.tick {
+				t.Fatalf("tick časovače: propuštěno %v, chci %v", msg != nil, tt.tick)
+			}
+			if _, _, msg := m.Update(ctrlC); (msg != nil) != tt.key {
+				t.Fatalf("ctrl+c: propuštěno %v, chci %v", msg != nil, tt.key)
+			}
+
+			// klávesy okna filtr neovlivní
+			if m, _, _ = m.Update(key("right")); m.selected() != 1 {
+				t.Fatal("klávesa Next nezměnila vybrané tlačítko")
+			}
+		})
+	}
+}
+
+func TestDismissalPaths(t *testing.T) {
+	tests := []struct {
+		name          string
+		keys          []string
+		defaultButton uint
+	}{
+		{"klávesa No", []string{"n"}, 0},
+		{"Esc", []string{"esc"}, 0},
+		{"Enter na ne", []string{"right", "enter"}, 0},
+		{"mezerník na ne", []string{"right", " "}, 0},
+		{"Enter na výchozím ne", []string{"enter"}, 1},
+		{"klávesa Show", []string{"q"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewQuitModel(WithDefaultButton(tt.defaultButton)).Display()
+
+			var (
+				cmd tea.Cmd
+				msg tea.Msg
+			)
+			for _, k := range tt.keys {
+				m, cmd, msg = m.Update(key(k))
+			}
+
+			if m.IsDisplayed() {
+				t.Fatal("okno se nezavřelo")
+			}
+			if msg != nil {
+				t.Fatalf("zrušení vrátilo zprávu %v, chci nil", msg)
+			}
+			if cmd == nil {
+				t.Fatal("zrušení nevrátilo tea.Cmd")
+			}
+			if _, ok := cmd().(CancelledMsg); !ok {
+				t.Fatal("zrušení nevrátilo CancelledMsg")
+			}
+			if got, ok := m.GetLastResult(); !ok || got != Cancelled {
+				t.Fatalf("GetLastResult() = %v, %v, chci Cancelled", got, ok)
+			}
+		})
+	}
+}
+
+func TestCountdownConfirmDisabled(t *testing.T) {
+	for _, buttons := range [][]string{nil, {"Smazat", "Zpět"}} {
+		m := NewQuitModel(
+			WithButtons(buttons...),
+			WithCountdown(time.Second, CountdownConfirm),
+			WithCountdownKeyMode(CountdownKeyIgnore),
+			WithTypedConfirmation("smazat"),
+		)
+		m, _ = m.DisplayCmd()
+		m, _, _ = m.Update(key("s"))
+
+		m, cmd, _ := m.Update(countdownTickMsg{id: m.id, tag: m.countdownTag})
+		if cmd != nil {
+			t.Fatalf("tlačítka %q: neaktivní potvrzení po odpočtu vrátilo tea.Cmd", buttons)
+		}
+		if !m.IsDisplayed() {
+			t.Fatalf("tlačítka %q: okno se po odpočtu zavřelo", buttons)
+		}
+		if m.countdownRunning || m.countdownShown {
+			t.Fatalf("tlačítka %q: odpočet po uplynutí běží dál", buttons)
+		}
+		if view := ansi.Strip(m.viewDialog()); strings.Contains(view, "(0s)") {
+			t.Fatalf("tlačítka %q: otázka zobrazuje odpočet:\n%s", buttons, view)
+		}
+		if _, ok := m.GetLastResult(); ok {
+			t.Fatalf("tlačítka %q: odpočet nastavil výsledek", buttons)
+		}
+	}
+}
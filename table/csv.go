@@ -0,0 +1,216 @@
+package table
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/acarl005/stripansi"
+)
+
+// CSVOption je volba pro načítání CSV, viz FromCSV() a WithCSV()
+type CSVOption func(*csvConfig)
+
+type csvConfig struct {
+	delimiter    rune
+	headers      []string
+	strict       bool
+	tableOptions []func(*TableModel)
+}
+
+// CSVDelimiter() nastaví oddělovač polí, pro TSV použít '\t'
+// Pokud není použito, je oddělovačem ','
+func CSVDelimiter(delimiter rune) CSVOption {
+	return func(c *csvConfig) {
+		c.delimiter = delimiter
+	}
+}
+
+// CSVHeaders() nastaví headery tabulky, první záznam CSV se pak bere jako data
+// Pokud není použito, jsou headery první záznam CSV
+func CSVHeaders(headers ...string) CSVOption {
+	return func(c *csvConfig) {
+		c.headers = headers
+	}
+}
+
+// CSVStrict() zapne kontrolu, že mají všechny záznamy stejný počet polí
+// Pokud není použito, kratší řádky se doplní prázdnými buňkami
+func CSVStrict(strict bool) CSVOption {
+	return func(c *csvConfig) {
+		c.strict = strict
+	}
+}
+
+// CSVTableOptions() předá další volby pro NewTableModel(), používá se jen ve FromCSV()
+func CSVTableOptions(options ...func(*TableModel)) CSVOption {
+	return func(c *csvConfig) {
+		c.tableOptions = append(c.tableOptions, options...)
+	}
+}
+
+// FromCSV() vytvoří TableModel z CSV načteného z r
+// Chyby čtení (špatné uvozovky, rozdílný počet polí s CSVStrict(true), CSV bez
+// headerů) vrací jako error
+func FromCSV(r io.Reader, opts ...CSVOption) (TableModel, error) {
+	var cfg csvConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	headers, rows, err := readCSV(r, cfg)
+	if err != nil {
+		return TableModel{}, err
+	}
+
+	options := append(
+		[]func(*TableModel){WithHeaders(headers...), WithContent(rows...)},
+		cfg.tableOptions...,
+	)
+
+	return NewTableModel(options...), nil
+}
+
+// WithCSV() nastaví headery a obsah tabulky z CSV načteného z r
+// Pokud se CSV nepodaří načíst, zobrazí se chyba jako text prázdné tabulky
+// (WithEmptyText()) a pokud nejsou zadány CSVHeaders(), použije se jediný
+// sloupeček "CSV", pro zpracování chyby použít FromCSV()
+// CSVTableOptions() se zde ignoruje
+func WithCSV(r io.Reader, opts ...CSVOption) func(*TableModel) {
+	return func(tm *TableModel) {
+		var cfg csvConfig
+		for _, opt := range opts {
+			opt(&cfg)
+		}
+
+		headers, rows, err := readCSV(r, cfg)
+		if err != nil {
+			tm.headers = cfg.headers
+			if len(tm.headers) == 0 {
+				tm.headers = []string{"CSV"}
+			}
+			tm.content = nil
+			tm.emptyText = err.Error()
+			return
+		}
+
+		tm.headers = headers
+		tm.content = rows
+	}
+}
+
+// readCSV() načte z r headery a řádky podle cfg, řádky doplní na počet headerů
+func readCSV(r io.Reader, cfg csvConfig) ([]string, [][]string, error) {
+	reader := csv.NewReader(r)
+	if cfg.delimiter != 0 {
+		reader.Comma = cfg.delimiter
+	}
+	reader.FieldsPerRecord = -1
+	if cfg.strict {
+		reader.FieldsPerRecord = 0
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("chyba čtení CSV: %w", err)
+	}
+
+	headers := cfg.headers
+	if len(headers) == 0 {
+		if len(records) == 0 {
+			return nil, nil, errors.New("chyba čtení CSV: chybí headery")
+		}
+		headers, records = records[0], records[1:]
+	}
+
+	for i, row := range records {
+		if len(row) < len(headers) {
+			records[i] = append(row, make([]string, len(headers)-len(row))...)
+		}
+	}
+
+	return headers, records, nil
+}
+
+// ExportCSV() zapíše do w zobrazené řádky jako CSV
+// Řádky jsou v pořadí zobrazení (s aktivním filtrem a řazením), sloupečky
+// v pořadí zobrazení, ANSI styly se z buněk odstraní
+// Pokud je includeHeaders == true, zapíše jako první záznam headery
+func (m TableModel) ExportCSV(w io.Writer, includeHeaders bool) error {
+	return m.exportCSV(w, ',', includeHeaders, false)
+}
+
+// ExportTSV() zapíše do w zobrazené řádky oddělené tabulátorem, viz ExportCSV()
+func (m TableModel) ExportTSV(w io.Writer, includeHeaders bool) error {
+	return m.exportCSV(w, '\t', includeHeaders, false)
+}
+
+// ExportMarkedCSV() zapíše do w jen označené zobrazené řádky, viz ExportCSV()
+func (m TableModel) ExportMarkedCSV(w io.Writer, includeHeaders bool) error {
+	return m.exportCSV(w, ',', includeHeaders, true)
+}
+
+// ExportMarkedTSV() zapíše do w jen označené zobrazené řádky oddělené tabulátorem,
+// viz ExportCSV()
+func (m TableModel) ExportMarkedTSV(w io.Writer, includeHeaders bool) error {
+	return m.exportCSV(w, '\t', includeHeaders, true)
+}
+
+// exportCSV() zapíše do w řádky z exportRows() s oddělovačem delimiter
+func (m TableModel) exportCSV(w io.Writer, delimiter rune, includeHeaders, markedOnly bool) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+
+	if includeHeaders {
+		if err := cw.Write(m.exportHeaders()); err != nil {
+			return err
+		}
+	}
+
+	if err := cw.WriteAll(m.exportRows(markedOnly)); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// exportHeaders() vrátí headery v pořadí zobrazení bez ANSI stylů
+func (m TableModel) exportHeaders() []string {
+	order := m.columnOrder()
+
+	headers := make([]string, len(order))
+	for n, col := range order {
+		headers[n] = stripansi.Strip(m.headers[col])
+	}
+
+	return headers
+}
+
+// exportRows() vrátí zobrazené řádky (s filtrem a řazením) se sloupečky v pořadí
+// zobrazení a bez ANSI stylů
+// Pokud je markedOnly == true, vrátí jen označené řádky
+func (m TableModel) exportRows(markedOnly bool) [][]string {
+	order := m.columnOrder()
+
+	rows := make([][]string, 0, len(m.sortedContent))
+	for line, row := range m.sortedContent {
+		if m.isGroupHeader(line) || markedOnly && !m.marked[m.sortedIndex[line]] {
+			continue
+		}
+
+		rows = append(rows, m.exportRow(row, order))
+	}
+
+	return rows
+}
+
+// exportRow() vrátí buňky řádku row ve sloupečcích order bez ANSI stylů
+func (m TableModel) exportRow(row []string, order []int) []string {
+	record := make([]string, len(order))
+	for n, col := range order {
+		record[n] = stripansi.Strip(cellAt(row, col))
+	}
+
+	return record
+}
@@ -0,0 +1,126 @@
+package table
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	input := "Název,Popis\n" +
+		"\"a, b\",\"první\nřádek\"\n" +
+		"c,\"uvozovky \"\"uvnitř\"\"\"\n"
+
+	m, err := FromCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromCSV() vrátilo chybu: %v", err)
+	}
+
+	want := [][]string{
+		{"a, b", "první\nřádek"},
+		{"c", "uvozovky \"uvnitř\""},
+	}
+	if got := m.GetContent(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("obsah = %q, chci %q", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := m.ExportCSV(&buf, true); err != nil {
+		t.Fatalf("ExportCSV() vrátilo chybu: %v", err)
+	}
+	if buf.String() != input {
+		t.Fatalf("export = %q, chci %q", buf.String(), input)
+	}
+}
+
+func TestCSVRaggedAndStrict(t *testing.T) {
+	input := "a,b,c\n1\n2,3,4\n"
+
+	m, err := FromCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromCSV() vrátilo chybu: %v", err)
+	}
+	if got := m.GetContent()[0]; !reflect.DeepEqual(got, []string{"1", "", ""}) {
+		t.Fatalf("doplněný řádek = %q", got)
+	}
+
+	if _, err := FromCSV(strings.NewReader(input), CSVStrict(true)); err == nil {
+		t.Fatal("FromCSV() s CSVStrict(true) nevrátilo chybu")
+	}
+}
+
+func TestCSVDelimiter(t *testing.T) {
+	m, err := FromCSV(strings.NewReader("a\tb\n1,5\t2\n"), CSVDelimiter('\t'))
+	if err != nil {
+		t.Fatalf("FromCSV() vrátilo chybu: %v", err)
+	}
+	if got := m.GetContent()[0]; !reflect.DeepEqual(got, []string{"1,5", "2"}) {
+		t.Fatalf("řádek = %q", got)
+	}
+}
+
+func TestWithCSVInvalid(t *testing.T) {
+	tests := map[string]string{
+		"špatné uvozovky": "a,b\n\"x,y\n",
+		"prázdné CSV":     "",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewTableModel(WithCSV(strings.NewReader(input)))
+			if len(m.GetHeaders()) == 0 {
+				t.Fatal("chybí náhradní headery")
+			}
+			if len(m.GetContent()) != 0 {
+				t.Fatalf("obsah = %q, chci prázdný", m.GetContent())
+			}
+			if m.emptyText == "" {
+				t.Fatal("chyba se nezobrazí jako text prázdné tabulky")
+			}
+			_ = m.SetSize(40, 10).View()
+		})
+	}
+}
+
+func TestExportReimport(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("Název", "Popis"),
+		WithContent(
+			[]string{"c", "tab\tuvnitř"},
+			[]string{"\x1b[1ma, b\x1b[0m", "první\nřádek"},
+			[]string{"b", "uvozovky \"uvnitř\""},
+			[]string{"x", "vyfiltrovaný"},
+		),
+	).Sort(0, SortAscendig).SetFilter("b")
+
+	want := [][]string{
+		{"a, b", "první\nřádek"},
+		{"b", "uvozovky \"uvnitř\""},
+		{"c", "tab\tuvnitř"},
+	}
+
+	for name, delimiter := range map[string]rune{"CSV": ',', "TSV": '\t'} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			export := m.ExportCSV
+			if delimiter == '\t' {
+				export = m.ExportTSV
+			}
+			if err := export(&buf, true); err != nil {
+				t.Fatalf("export vrátil chybu: %v", err)
+			}
+
+			got, err := FromCSV(&buf, CSVDelimiter(delimiter), CSVStrict(true))
+			if err != nil {
+				t.Fatalf("FromCSV() vrátilo chybu: %v", err)
+			}
+			if !reflect.DeepEqual(got.GetHeaders(), m.GetHeaders()) {
+				t.Fatalf("headery = %q, chci %q", got.GetHeaders(), m.GetHeaders())
+			}
+			if !reflect.DeepEqual(got.GetContent(), want) {
+				t.Fatalf("obsah = %q, chci %q", got.GetContent(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package table
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tomaspantlik/crapmodels/window"
+)
+
+// OpenDetail() otevře okno s detailem vybraného řádku (všechny buňky celé
+// a zalomené, s WithColumnCursor(true) jen vybraná buňka), okno je vykreslené
+// uprostřed tabulky
+// Pokud není vybraný žádný řádek, nic nedělá
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) OpenDetail() TableModel {
+	if m.selectedContentIndex() < 0 || m.loading {
+		return m
+	}
+
+	m.detailOpen = true
+	m.detailScroll = 0
+
+	return m
+}
+
+// CloseDetail() zavře okno s detailem
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) CloseDetail() TableModel {
+	m.detailOpen = false
+	m.detailScroll = 0
+
+	return m
+}
+
+// IsDetailOpen() vrátí true, pokud je otevřené okno s detailem
+func (m TableModel) IsDetailOpen() bool {
+	return m.detailOpen
+}
+
+// handleDetailKey() zpracuje klávesu při otevřeném detailu
+// Klávesy pro pohyb v tabulce posouvají obsah detailu, Esc a Detail detail
+// zavřou, ostatní klávesy tabulky se zahodí a cizí klávesy posílá zpět
+func (m TableModel) handleDetailKey(msg tea.KeyMsg) (TableModel, tea.Cmd, tea.Msg) {
+	_, visible := m.detailSize()
+
+	switch msg.String() {
+	case tea.KeyEsc.String(), m.keys.Detail1, m.keys.Detail2, m.keys.Detail3:
+		m = m.CloseDetail()
+
+	case m.keys.SelectLineDown1, m.keys.SelectLineDown2, m.keys.SelectLineDown3,
+		m.keys.MoveViewDown1, m.keys.MoveViewDown2, m.keys.MoveViewDown3:
+		m = m.scrollDetail(1)
+
+	case m.keys.SelectLineUp1, m.keys.SelectLineUp2, m.keys.SelectLineUp3,
+		m.keys.MoveViewUp1, m.keys.MoveViewUp2, m.keys.MoveViewUp3:
+		m = m.scrollDetail(-1)
+
+	case m.keys.PageDown1, m.keys.PageDown2, m.keys.PageDown3:
+		m = m.scrollDetail(visible)
+
+	case m.keys.PageUp1, m.keys.PageUp2, m.keys.PageUp3:
+		m = m.scrollDetail(-visible)
+
+	case m.keys.Top1, m.keys.Top2, m.keys.Top3:
+		m.detailScroll = 0
+
+	case m.keys.Bottom1, m.keys.Bottom2, m.keys.Bottom3:
+		m = m.scrollDetail(len(m.detailLines()))
+
+	default:
+		if !m.keys.contains(msg.String()) {
+			return m, nil, msg
+		}
+	}
+
+	return m, nil, nil
+}
+
+// handleDetailMouse() zpracuje událost myši při otevřeném detailu
+// Kolečko posouvá obsah detailu, ostatní události nad tabulkou se zahodí
+func (m TableModel) handleDetailMouse(msg tea.MouseMsg) (TableModel, tea.Cmd, tea.Msg) {
+	if !m.inArea(msg.X, msg.Y) {
+		return m, nil, msg
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m = m.scrollDetail(-m.wheelDelta)
+	case tea.MouseButtonWheelDown:
+		m = m.scrollDetail(m.wheelDelta)
+	}
+
+	return m, nil, nil
+}
+
+// scrollDetail() posune obsah detailu o num řádků, nejvýše na začátek/konec
+func (m TableModel) scrollDetail(num int) TableModel {
+	_, visible := m.detailSize()
+	maxScroll := max(len(m.detailLines())-visible, 0)
+
+	m.detailScroll = min(max(m.detailScroll+num, 0), maxScroll)
+
+	return m
+}
+
+// detailText() vrátí titulek a text detailu vybraného řádku
+// Každá buňka (v pořadí zobrazení sloupečků) je na vlastním řádku ve tvaru
+// "Header: hodnota", s kurzorem sloupečku je titulkem header a textem vybraná buňka
+func (m TableModel) detailText() (title, text string) {
+	i := m.selectedContentIndex()
+	if i < 0 {
+		return "", ""
+	}
+
+	if col := m.selectedColumn(); col >= 0 {
+		return m.headers[col], m.formatCell(m.content[i], col)
+	}
+
+	var lines []string
+	for _, col := range m.columnOrder() {
+		lines = append(lines, m.headers[col]+": "+m.formatCell(m.content[i], col))
+	}
+
+	return "Detail", strings.Join(lines, "\n")
+}
+
+// detailLines() vrátí řádky textu detailu zalomené na šířku okna detailu
+func (m TableModel) detailLines() []string {
+	width, _ := m.detailSize()
+	_, text := m.detailText()
+
+	return strings.Split(wrapCell(text, max(width-2, 1)), "\n")
+}
+
+// detailSize() vrátí šířku okna detailu a počet řádků textu, které se do něj
+// vejdou (výška okna bez okrajů)
+func (m TableModel) detailSize() (width, visible int) {
+	width = min(max(m.width*2/3, 20), m.width-2)
+	visible = max(m.height-4, 1)
+
+	return width, visible
+}
+
+// viewDetail() vykreslí přes vykreslenou tabulku table okno s detailem
+func (m TableModel) viewDetail(table string) string {
+	width, visible := m.detailSize()
+	if width < 5 || m.height < 3 {
+		return table
+	}
+
+	var (
+		lines     = m.detailLines()
+		title, _  = m.detailText()
+		scroll    = min(m.detailScroll, max(len(lines)-visible, 0))
+		shown     = lines[scroll:min(scroll+visible, len(lines))]
+		height    = len(shown) + 2
+		popupView = window.NewWindowModel(
+			window.WithTitle(title),
+			window.WithBorderType(m.borderType),
+			window.WithContent(strings.Join(shown, "\n")),
+			window.WithContentPosition(lipgloss.Top, lipgloss.Left),
+		).SetSize(width, height).View()
+	)
+
+	x, y := window.Place(lipgloss.Width(table), lipgloss.Height(table), width, height, lipgloss.Center, lipgloss.Center)
+
+	return window.Overlay(table, popupView, x, y)
+}
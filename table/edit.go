@@ -0,0 +1,206 @@
+package table
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CellEditedMsg je zpráva, kterou vrací tea.Cmd z Update() po potvrzení úpravy
+// buňky klávesou Enter, viz WithEditable()
+// Row je index řádku v obsahu (GetContent()), Col index sloupečku v headerech,
+// Old a New jsou hodnoty buňky před a po úpravě (New už převedená přes
+// WithColParser())
+type CellEditedMsg struct {
+	Row, Col int
+	Old, New string
+}
+
+// cellEdited() vrátí tea.Cmd, který pošle CellEditedMsg
+func cellEdited(msg CellEditedMsg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// WithEditable() zapne úpravu buněk, klávesa Edit otevře na místě vybrané buňky
+// editor, Enter úpravu potvrdí (tea.Cmd s CellEditedMsg) a Esc ji zruší
+// Upravovat jde jen sloupečky cols, pokud nejsou předané, jdou upravovat všechny
+// Bez WithColumnCursor() se upravuje první upravitelný sloupeček v pořadí zobrazení
+func WithEditable(cols ...int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.editable = true
+		tm.editableCols = cols
+	}
+}
+
+// WithResortOnEdit() nastaví, jestli se tabulka po potvrzení úpravy znovu seřadí
+// a přefiltruje, při false zůstane upravený řádek na svém místě až do další změny
+// obsahu, filtru nebo řazení
+// Pokud není použito, tabulka se znovu seřadí
+func WithResortOnEdit(resort bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.resortOnEdit = resort
+	}
+}
+
+// StartEdit() otevře editor vybrané buňky, viz WithEditable()
+// Editor obsahuje původní hodnotu buňky, s WithColParser() hodnotu upravenou
+// formátovačem sloupečku
+// Pokud úprava není zapnutá, není vybraný řádek nebo sloupeček nejde upravovat,
+// nic nedělá
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu a tea.Cmd
+// pro blikání kurzoru, který je potřeba vrátit do bubbletea
+func (m TableModel) StartEdit() (TableModel, tea.Cmd) {
+	i := m.selectedContentIndex()
+	col := m.editColumn()
+	if i < 0 || col < 0 || m.loading {
+		return m, nil
+	}
+
+	_, colSizes := m.layoutColumns()
+
+	value := cellAt(m.content[i], col)
+	if m.colParsers[col] != nil {
+		value = m.formatCell(m.content[i], col)
+	}
+
+	m.editInput = textinput.New()
+	m.editInput.Prompt = ""
+	m.editInput.Width = max(colSizes[col]-1, 1)
+	m.editInput.SetValue(value)
+	cmd := m.editInput.Focus()
+
+	m.editActive = true
+	m.editRow, m.editCol = i, col
+
+	return m, cmd
+}
+
+// CancelEdit() zavře editor bez uložení změny
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) CancelEdit() TableModel {
+	m.editActive = false
+	m.editInput.Blur()
+
+	return m
+}
+
+// IsEditing() vrátí true, pokud je otevřený editor buňky
+func (m TableModel) IsEditing() bool {
+	return m.editActive
+}
+
+// commitEdit() uloží hodnotu z editoru do buňky a zavře editor
+// S WithColParser() hodnotu nejdřív převede, pokud převod selže, nechá editor
+// otevřený
+// Vrací tea.Cmd s CellEditedMsg
+func (m TableModel) commitEdit() (TableModel, tea.Cmd) {
+	value := m.editInput.Value()
+	if parse := m.colParsers[m.editCol]; parse != nil {
+		parsed, err := parse(value)
+		if err != nil {
+			return m, nil
+		}
+		value = parsed
+	}
+
+	m = m.CancelEdit()
+	if m.editRow >= len(m.content) {
+		return m, nil
+	}
+
+	msg := CellEditedMsg{
+		Row: m.editRow,
+		Col: m.editCol,
+		Old: cellAt(m.content[m.editRow], m.editCol),
+		New: value,
+	}
+
+	if m.resortOnEdit {
+		m = m.UpdateCell(msg.Row, msg.Col, msg.New)
+	} else {
+		m = m.updateCellInPlace(msg.Row, msg.Col, msg.New)
+	}
+
+	return m, cellEdited(msg)
+}
+
+// updateCellInPlace() nastaví hodnotu buňky bez nového filtrování a řazení,
+// řádek zůstane na místě, kde je zobrazený
+func (m TableModel) updateCellInPlace(row, col int, value string) TableModel {
+	cells := slices.Clone(m.content[row])
+	if len(cells) <= col {
+		cells = append(cells, make([]string, col-len(cells)+1)...)
+	}
+	cells[col] = value
+
+	m.content = slices.Clone(m.content)
+	m.content[row] = cells
+
+	if line := slices.Index(m.sortedIndex, row); line >= 0 {
+		m.sortedContent = slices.Clone(m.sortedContent)
+		m.sortedContent[line] = cells
+	}
+	if n := slices.Index(m.filteredIndex, row); n >= 0 {
+		m.filteredContent = slices.Clone(m.filteredContent)
+		m.filteredContent[n] = cells
+	}
+	if m.footerFunc != nil {
+		m.footer = m.footerFunc(m.filteredContent)
+	}
+
+	m.natural = m.measureColSizes()
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// handleEditKey() zpracuje klávesu při otevřeném editoru
+// Enter úpravu potvrdí, Esc zruší, ostatní klávesy dostane editor
+func (m TableModel) handleEditKey(msg tea.KeyMsg) (TableModel, tea.Cmd, tea.Msg) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m = m.CancelEdit()
+
+	case tea.KeyEnter:
+		m, cmd = m.commitEdit()
+
+	default:
+		m.editInput, cmd = m.editInput.Update(msg)
+	}
+
+	return m, cmd, nil
+}
+
+// editColumn() vrátí sloupeček, který se upraví klávesou Edit, pokud žádný
+// upravovat nejde, vrátí -1
+func (m TableModel) editColumn() int {
+	if !m.editable {
+		return -1
+	}
+
+	if col := m.selectedColumn(); col >= 0 {
+		if !m.isEditable(col) {
+			return -1
+		}
+
+		return col
+	}
+
+	for _, col := range m.columnOrder() {
+		if m.isEditable(col) {
+			return col
+		}
+	}
+
+	return -1
+}
+
+// isEditable() vrátí true, pokud jde upravovat sloupeček col
+func (m TableModel) isEditable(col int) bool {
+	return m.editable && (len(m.editableCols) == 0 || slices.Contains(m.editableCols, col))
+}
@@ -0,0 +1,99 @@
+package table
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// parseBytes() převede "N B" zpět na počet bajtů
+func parseBytes(text string) (string, error) {
+	n, ok := strings.CutSuffix(strings.TrimSpace(text), " B")
+	if !ok {
+		return "", errors.New("chybí jednotka")
+	}
+
+	return n, nil
+}
+
+func TestEditFormattedColumn(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("Název", "Velikost"),
+		WithContent([]string{"a", "12"}),
+		WithColFormatter(1, Bytes),
+		WithColParser(1, parseBytes),
+		WithEditable(1),
+	).SetSize(40, 10)
+
+	m, cmd := m.StartEdit()
+	if !m.IsEditing() {
+		t.Fatal("editor se neotevřel")
+	}
+	if cmd == nil {
+		t.Fatal("StartEdit() nevrátilo tea.Cmd pro kurzor")
+	}
+	if got := m.editInput.Value(); got != "12 B" {
+		t.Fatalf("editor obsahuje %q, chci %q", got, "12 B")
+	}
+
+	m.editInput.SetValue("30")
+	m, cmd, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.IsEditing() || cmd != nil {
+		t.Fatal("nepřevoditelná hodnota editor zavřela")
+	}
+
+	m.editInput.SetValue("30 B")
+	m, cmd, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsEditing() {
+		t.Fatal("editor se po potvrzení nezavřel")
+	}
+	if got := m.GetContent()[0][1]; got != "30" {
+		t.Fatalf("uložená hodnota = %q, chci %q", got, "30")
+	}
+
+	var edited CellEditedMsg
+	for _, msg := range collect(cmd) {
+		if e, ok := msg.(CellEditedMsg); ok {
+			edited = e
+		}
+	}
+	if edited != (CellEditedMsg{Row: 0, Col: 1, Old: "12", New: "30"}) {
+		t.Fatalf("CellEditedMsg = %+v", edited)
+	}
+}
+
+func TestEditRawColumn(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("Velikost"),
+		WithContent([]string{"2048"}),
+		WithColFormatter(0, Bytes),
+		WithEditable(),
+	).SetSize(40, 10)
+
+	m, _ = m.StartEdit()
+	if got := m.editInput.Value(); got != "2048" {
+		t.Fatalf("editor obsahuje %q, chci původní hodnotu", got)
+	}
+}
+
+// collect() spustí cmd a vrátí všechny zprávy, tea.BatchMsg rozbalí
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, collect(c)...)
+	}
+
+	return msgs
+}
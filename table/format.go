@@ -0,0 +1,112 @@
+package table
+
+import (
+	"cmp"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColFormatter upravuje hodnotu buňky pro zobrazení, viz WithColFormatter()
+// Hodnoty, které formátovač neumí zpracovat, by měl vrátit beze změny
+type ColFormatter func(value string) string
+
+// ColParser převede text zadaný v editoru buňky zpět na hodnotu obsahu, je to
+// opak ColFormatter, viz WithColParser()
+// Pokud text nejde převést, vrátí error a editor zůstane otevřený
+type ColParser func(text string) (string, error)
+
+// Bytes() zformátuje počet bajtů na čitelnou velikost (např. "1.5 KiB")
+// Hodnotu, která není celé číslo, vrátí beze změny
+func Bytes(value string) string {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return value
+	}
+
+	const unit = 1024
+	if n < unit && n > -unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	f := float64(n)
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	i := -1
+	for (f >= unit || f <= -unit) && i < len(units)-1 {
+		f /= unit
+		i++
+	}
+
+	return strconv.FormatFloat(f, 'f', 1, 64) + " " + units[i]
+}
+
+// Duration() zformátuje dobu trvání, hodnotou může být počet sekund nebo text
+// pro time.ParseDuration(), výsledek je zaokrouhlený na sekundy (např. "1h2m3s")
+// Hodnotu, kterou nelze převést, vrátí beze změny
+func Duration(value string) string {
+	value = strings.TrimSpace(value)
+
+	if n, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+		return (time.Duration(n * float64(time.Second))).Round(time.Second).String()
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return d.Round(time.Second).String()
+	}
+
+	return value
+}
+
+// Time() vrátí formátovač, který převede unixový čas v sekundách na text
+// ve formátu layout (v lokálním čase)
+// Hodnotu, která není celé číslo, vrátí beze změny
+func Time(layout string) ColFormatter {
+	return func(value string) string {
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return value
+		}
+
+		return time.Unix(n, 0).Format(layout)
+	}
+}
+
+// ColCompare porovná dvě původní hodnoty sloupečku pro řazení, vrací záporné
+// číslo pro a < b, 0 pro a == b a kladné číslo pro a > b, viz WithColCompare()
+type ColCompare func(a, b string) int
+
+// CompareNumeric() porovná hodnoty jako čísla (např. počty bajtů nebo unixový čas)
+// Hodnoty, které nejsou čísla, řadí za čísla a mezi sebou jako text
+func CompareNumeric(a, b string) int {
+	x, errA := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errB := strconv.ParseFloat(strings.TrimSpace(b), 64)
+
+	return compareParsed(x, y, errA, errB, a, b)
+}
+
+// CompareTime() vrátí porovnání hodnot jako času ve formátu layout
+// Hodnoty, které nejde převést, řadí za časy a mezi sebou jako text
+func CompareTime(layout string) ColCompare {
+	return func(a, b string) int {
+		x, errA := time.Parse(layout, strings.TrimSpace(a))
+		y, errB := time.Parse(layout, strings.TrimSpace(b))
+
+		return compareParsed(x.UnixNano(), y.UnixNano(), errA, errB, a, b)
+	}
+}
+
+// compareParsed() porovná převedené hodnoty x a y, nepřevedené (s chybou) řadí
+// na konec a mezi sebou porovná jejich text a a b
+func compareParsed[T cmp.Ordered](x, y T, errA, errB error, a, b string) int {
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(x, y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
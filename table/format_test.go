@@ -0,0 +1,73 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		compare ColCompare
+		values  []string
+		want    []string
+	}{
+		{
+			name:    "text",
+			compare: nil,
+			values:  []string{"10", "9", "100"},
+			want:    []string{"10", "100", "9"},
+		},
+		{
+			name:    "čísla",
+			compare: CompareNumeric,
+			values:  []string{"10", "x", "9", "-1.5", "100"},
+			want:    []string{"-1.5", "9", "10", "100", "x"},
+		},
+		{
+			name:    "datum",
+			compare: CompareTime("2.1.2006"),
+			values:  []string{"1.12.2024", "2.3.2024", "", "15.3.2023"},
+			want:    []string{"15.3.2023", "2.3.2024", "1.12.2024", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := make([][]string, len(tt.values))
+			for i, v := range tt.values {
+				content[i] = []string{v}
+			}
+
+			m := NewTableModel(WithHeaders("A"), WithContent(content...), WithColCompare(0, tt.compare)).
+				Sort(0, SortAscendig)
+
+			var got []string
+			for _, row := range m.sortedContent {
+				got = append(got, row[0])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("pořadí = %q, chci %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatters(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{Bytes("512"), "512 B"},
+		{Bytes("1536"), "1.5 KiB"},
+		{Bytes("abc"), "abc"},
+		{Duration("3723"), "1h2m3s"},
+		{Duration("90s"), "1m30s"},
+		{Duration("x"), "x"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%q, chci %q", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package table
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "přepíše soubory v testdata aktuálním výstupem")
+
+// golden() porovná got se souborem testdata/name.golden, s -update soubor přepíše
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("chybí %s, spustit s -update: %v", path, err)
+	}
+	if got != string(want) {
+		t.Fatalf("výstup se liší od %s\nmám:\n%s\nchci:\n%s", path, got, want)
+	}
+}
+
+func TestGoldenStyledCells(t *testing.T) {
+	// styly tabulky nezávisí na terminálu, v souborech zůstanou jen styly buněk
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	content := [][]string{
+		{"\x1b[1mtučné\x1b[0m", "\x1b[31mčervený dlouhý text\x1b[0m", "漢字テキスト"},
+		{"\x1b[4mpodtržené\x1b[0m", "obyčejný", "\x1b[32m🙂 zelený\x1b[0m"},
+		{"", "\x1b[1;34mmodrý\x1b[0m a obyčejný", "x"},
+	}
+
+	for _, width := range []int{20, 32, 50} {
+		t.Run(strconv.Itoa(width), func(t *testing.T) {
+			m := NewTableModel(
+				WithHeaders("Styl", "Text", "Široké"),
+				WithContent(content...),
+			).SetSize(width, 8)
+
+			golden(t, "styled_cells_"+strconv.Itoa(width), m.View())
+		})
+	}
+}
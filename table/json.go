@@ -0,0 +1,89 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExportJSON() zapíše do w zobrazené řádky jako pole JSON objektů
+// Klíče jsou headery v pořadí zobrazení sloupečků, řádky jsou v pořadí zobrazení
+// (s aktivním filtrem a řazením), ANSI styly se z hodnot odstraní
+// Prázdný header se nahradí "colN" (N je pozice sloupečku), opakující se
+// headery dostanou příponu "_2", "_3", ...
+func (m TableModel) ExportJSON(w io.Writer) error {
+	return m.exportJSON(w, false)
+}
+
+// ExportMarkedJSON() zapíše do w jen označené zobrazené řádky, viz ExportJSON()
+func (m TableModel) ExportMarkedJSON(w io.Writer) error {
+	return m.exportJSON(w, true)
+}
+
+// exportJSON() zapíše do w řádky z exportRows() jako pole JSON objektů
+// Objekty se skládají ručně, aby klíče zůstaly v pořadí sloupečků
+func (m TableModel) exportJSON(w io.Writer, markedOnly bool) error {
+	var keys [][]byte
+	for _, key := range jsonKeys(m.exportHeaders()) {
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for r, row := range m.exportRows(markedOnly) {
+		if r > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteByte('{')
+		for n, value := range row {
+			v, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(keys[n])
+			buf.WriteByte(':')
+			buf.Write(v)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString("]\n")
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// jsonKeys() vrátí z headerů unikátní klíče pro JSON objekty
+func jsonKeys(headers []string) []string {
+	var (
+		keys = make([]string, len(headers))
+		used = make(map[string]bool, len(headers))
+	)
+
+	for n, h := range headers {
+		key := strings.TrimSpace(h)
+		if key == "" {
+			key = fmt.Sprintf("col%d", n)
+		}
+
+		unique := key
+		for i := 2; used[unique]; i++ {
+			unique = fmt.Sprintf("%s_%d", key, i)
+		}
+
+		used[unique] = true
+		keys[n] = unique
+	}
+
+	return keys
+}
@@ -0,0 +1,46 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestExportJSON(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("Název", "Čas ⏱", " ", "Název"),
+		WithContent(
+			[]string{"žluťoučký", "", "", "漢字"},
+			[]string{"", "\x1b[1m12:00\x1b[0m", "x", ""},
+		),
+	)
+
+	var buf bytes.Buffer
+	if err := m.ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON() vrátilo chybu: %v", err)
+	}
+
+	want := `[{"Název":"žluťoučký","Čas ⏱":"","col2":"","Název_2":"漢字"},` +
+		`{"Název":"","Čas ⏱":"12:00","col2":"x","Název_2":""}]` + "\n"
+	if buf.String() != want {
+		t.Fatalf("export = %s, chci %s", buf.String(), want)
+	}
+
+	var rows []map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("export není platný JSON: %v", err)
+	}
+	if len(rows) != 2 || len(rows[0]) != 4 {
+		t.Fatalf("načteno %v", rows)
+	}
+}
+
+func TestExportJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewTableModel(WithHeaders("A")).ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON() vrátilo chybu: %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Fatalf("export prázdné tabulky = %q", buf.String())
+	}
+}
@@ -0,0 +1,154 @@
+package table
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RowLoader je funkce, která vrací tea.Cmd pro načtení limit řádků od řádku
+// offset, tea.Cmd musí vrátit RowsLoadedMsg, viz WithRowLoader()
+type RowLoader func(offset, limit int) tea.Cmd
+
+// RowsLoadedMsg je zpráva, kterou vrací tea.Cmd z RowLoader
+// Offset je index prvního načteného řádku, Rows načtené řádky a Total celkový
+// počet řádků (0, pokud není známý)
+// Prázdné Rows znamenají, že další řádky už nejsou
+type RowsLoadedMsg struct {
+	Offset int
+	Rows   [][]string
+	Total  int
+}
+
+// rowsLoadedMsg je RowsLoadedMsg pro konkrétní tabulku a požadavek
+type rowsLoadedMsg struct {
+	id  int64
+	tag int
+	msg RowsLoadedMsg
+}
+
+// WithRowLoader() nastaví funkci pro postupné načítání řádků
+// První část se načte z Init(), další vždy, když se výběr nebo pohled přiblíží
+// ke konci načtených řádků (viz WithRowLoaderChunk()), načtené řádky se přidají
+// na konec obsahu
+// Během načítání je pod posledním řádkem řádek s textem načítání, najednou běží
+// jen jeden požadavek, výsledky zrušených požadavků (ReloadRows()) a výsledky
+// s jiným Offset, než byl požadován, se zahodí
+// Offset se počítá ze všech načtených řádků, i když je WithMaxRows() zahodí
+// Procenta a scrollbar se počítají z Total v RowsLoadedMsg
+func WithRowLoader(loader RowLoader) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.rowLoader = loader
+	}
+}
+
+// WithRowLoaderChunk() nastaví počet řádků načítaných najednou (limit) a počet
+// řádků od konce načtených řádků, při kterém se načítá další část (threshold)
+// Pokud není použito, načítá se po 100 řádcích 20 řádků před koncem
+func WithRowLoaderChunk(limit, threshold int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.loaderLimit = limit
+		tm.loaderThreshold = threshold
+	}
+}
+
+// ReloadRows() zahodí obsah a načte řádky znovu od začátku, výsledky dřívějších
+// požadavků se zahodí
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu a tea.Cmd,
+// který je potřeba vrátit do bubbletea
+func (m TableModel) ReloadRows() (TableModel, tea.Cmd) {
+	if m.rowLoader == nil {
+		return m, nil
+	}
+
+	m.loaderTag++
+	m.loaderPending = false
+	m.loaderTotal = 0
+	m.loaderOffset = 0
+	m.loaderDone = false
+	m = m.SetContent()
+
+	return m.requestRows()
+}
+
+// IsLoadingRows() vrátí true, pokud čeká na RowsLoadedMsg
+func (m TableModel) IsLoadingRows() bool {
+	return m.loaderPending
+}
+
+// requestRows() vrátí tea.Cmd pro načtení další části řádků
+func (m TableModel) requestRows() (TableModel, tea.Cmd) {
+	cmd := m.loadRowsCmd()
+	if cmd == nil {
+		return m, nil
+	}
+
+	m.loaderPending = true
+	m.rev = lastRev.Add(1)
+
+	return m, cmd
+}
+
+// loadRowsCmd() vrátí tea.Cmd, který načte řádky za posledním načteným řádkem
+// a výsledek označí tabulkou a aktuálním požadavkem
+func (m TableModel) loadRowsCmd() tea.Cmd {
+	cmd := m.rowLoader(m.loaderOffset, m.loaderLimit)
+	if cmd == nil {
+		return nil
+	}
+
+	id, tag := m.id, m.loaderTag
+
+	return func() tea.Msg {
+		msg := cmd()
+		if loaded, ok := msg.(RowsLoadedMsg); ok {
+			return rowsLoadedMsg{id: id, tag: tag, msg: loaded}
+		}
+
+		return msg
+	}
+}
+
+// maybeLoadRows() spustí načítání další části řádků, pokud je výběr nebo konec
+// pohledu blízko konce načtených řádků a žádné načítání neběží
+func (m TableModel) maybeLoadRows() (TableModel, tea.Cmd) {
+	if m.rowLoader == nil || m.loaderPending || !m.moreRows() {
+		return m, nil
+	}
+
+	if max(m.selectedLine, m.lastVisibleRow()) < len(m.sortedContent)-1-m.loaderThreshold {
+		return m, nil
+	}
+
+	return m.requestRows()
+}
+
+// rowsLoaded() přidá načtené řádky na konec obsahu
+// Odpověď s jiným Offset, než byl požadován, zahodí, ukončí načítání a vrátí
+// false, další část se pak požádá až při dalším posunu
+func (m TableModel) rowsLoaded(msg RowsLoadedMsg) (TableModel, bool) {
+	m.loaderPending = false
+	if msg.Offset != m.loaderOffset {
+		m.rev = lastRev.Add(1)
+		return m, false
+	}
+
+	m.loaderTotal = msg.Total
+	m.loaderDone = len(msg.Rows) == 0
+	m.loaderOffset += len(msg.Rows)
+
+	return m.AppendContent(msg.Rows...), true
+}
+
+// moreRows() vrátí true, pokud má RowLoader ještě další řádky
+func (m TableModel) moreRows() bool {
+	return !m.loaderDone && (m.loaderTotal <= 0 || m.loaderOffset < m.loaderTotal)
+}
+
+// unloadedRows() vrátí počet ještě nenačtených řádků podle Total z RowsLoadedMsg
+// S filtrem vrací 0, počet nenačtených řádků po filtrování není známý
+func (m TableModel) unloadedRows() int {
+	if m.rowLoader == nil || m.filter != "" {
+		return 0
+	}
+
+	return max(m.loaderTotal-m.loaderOffset, 0)
+}
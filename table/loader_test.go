@@ -0,0 +1,97 @@
+package table
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// source vrací RowLoader nad total řádky a zaznamenává požadované offsety
+func source(total int, offsets *[]int) RowLoader {
+	return func(offset, limit int) tea.Cmd {
+		*offsets = append(*offsets, offset)
+
+		return func() tea.Msg {
+			var rows [][]string
+			for i := offset; i < min(offset+limit, total); i++ {
+				rows = append(rows, []string{strconv.Itoa(i)})
+			}
+			return RowsLoadedMsg{Offset: offset, Rows: rows, Total: total}
+		}
+	}
+}
+
+// loadAll() posílá výsledky tea.Cmd do tabulky, dokud nějaké vrací
+func loadAll(t *testing.T, m TableModel, cmd tea.Cmd) TableModel {
+	t.Helper()
+
+	for range 100 {
+		if cmd == nil {
+			return m
+		}
+		m, cmd, _ = m.Update(cmd())
+	}
+
+	t.Fatal("načítání neskončilo")
+	return m
+}
+
+func TestRowLoaderMaxRows(t *testing.T) {
+	var offsets []int
+	m := NewTableModel(
+		WithHeaders("N"),
+		WithRowLoader(source(10, &offsets)),
+		WithRowLoaderChunk(3, 20),
+		WithMaxRows(5),
+	).SetSize(20, 10)
+
+	m = loadAll(t, m, m.Init())
+
+	if want := []int{0, 3, 6, 9}; !reflect.DeepEqual(offsets, want) {
+		t.Fatalf("offsety = %v, chci %v", offsets, want)
+	}
+
+	want := [][]string{{"5"}, {"6"}, {"7"}, {"8"}, {"9"}}
+	if got := m.GetContent(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("obsah = %q, chci %q", got, want)
+	}
+	if m.IsLoadingRows() || m.unloadedRows() != 0 {
+		t.Fatal("načítání neskončilo")
+	}
+}
+
+func TestRowLoaderStaleOffset(t *testing.T) {
+	var offsets []int
+	m := NewTableModel(
+		WithHeaders("N"),
+		WithRowLoader(source(100, &offsets)),
+		WithRowLoaderChunk(3, 0),
+	).SetSize(20, 10)
+
+	m, _, _ = m.Update(m.Init()())
+	m, cmd := m.requestRows()
+	if cmd == nil || !m.IsLoadingRows() {
+		t.Fatal("další část se nenačítá")
+	}
+
+	stale := rowsLoadedMsg{id: m.id, tag: m.loaderTag, msg: RowsLoadedMsg{
+		Offset: 0,
+		Rows:   [][]string{{"x"}},
+		Total:  100,
+	}}
+	m, _, _ = m.Update(stale)
+
+	if got := len(m.GetContent()); got != 3 {
+		t.Fatalf("počet řádků po zastaralé odpovědi = %d, chci 3", got)
+	}
+	if m.IsLoadingRows() {
+		t.Fatal("načítání po zastaralé odpovědi pořád čeká")
+	}
+
+	_, _ = m.requestRows()
+	if got := offsets[len(offsets)-1]; got != 3 {
+		t.Fatalf("další požadavek má offset %d, chci 3", got)
+	}
+}
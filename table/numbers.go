@@ -0,0 +1,167 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WithRowNumbers() zapne sloupeček s čísly řádků před prvním sloupečkem
+// Čísla začínají od 1 a odpovídají pořadí zobrazení (po filtrování a řazení,
+// řádky skupin se nečíslují), nejsou součástí obsahu, nefiltruje ani neřadí se
+// podle nich, šířka sloupečku se řídí počtem řádků
+func WithRowNumbers(show bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.rowNumbers = show
+	}
+}
+
+// SetRowNumbers() zapne nebo vypne sloupeček s čísly řádků, viz WithRowNumbers()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetRowNumbers(show bool) TableModel {
+	m.rowNumbers = show
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// StartGoTo() otevře místo řádku s filtrem řádek pro zadání čísla řádku
+// Enter vybere řádek se zadaným číslem (viz WithRowNumbers()), Esc zadání zruší
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) StartGoTo() TableModel {
+	m.gotoInput = textinput.New()
+	m.gotoInput.Prompt = " Řádek: "
+	m.gotoInput.Width = max(m.innerWidth()-9, 1)
+	m.gotoInput.TextStyle = m.filterStyle
+	m.gotoInput.PromptStyle = m.filterStyle
+	m.gotoInput.Cursor.Style = m.filterStyle
+	m.gotoInput.Focus()
+
+	m.gotoActive = true
+
+	return m
+}
+
+// GoTo() vybere řádek s číslem n (číslování od 1 podle WithRowNumbers()), číslo
+// mimo rozsah se omezí na první/poslední řádek
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) GoTo(n int) TableModel {
+	return m.SetSelectedLine(m.lineOfNumber(n))
+}
+
+// handleGoToKey() zpracuje klávesu při zadávání čísla řádku
+// Enter skočí na řádek, Esc zadání zruší, jiné znaky než číslice se zahodí
+func (m TableModel) handleGoToKey(msg tea.KeyMsg) (TableModel, tea.Cmd, tea.Msg) {
+	var cmd tea.Cmd
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.gotoActive = false
+
+	case tea.KeyEnter:
+		m.gotoActive = false
+		if n, err := strconv.Atoi(m.gotoInput.Value()); err == nil {
+			m = m.GoTo(n)
+		}
+
+	case tea.KeyRunes:
+		if strings.Trim(string(msg.Runes), "0123456789") != "" {
+			return m, nil, nil
+		}
+		m.gotoInput, cmd = m.gotoInput.Update(msg)
+
+	default:
+		m.gotoInput, cmd = m.gotoInput.Update(msg)
+	}
+
+	return m, cmd, nil
+}
+
+// hasInputLine() vrátí true, pokud je nad headery řádek s filtrem nebo se zadáním
+// čísla řádku
+func (m TableModel) hasInputLine() bool {
+	return m.filter != "" || m.filterInputDisplayed || m.gotoActive
+}
+
+// rowNumber() vrátí číslo zobrazeného řádku line (od 1, bez řádků skupin)
+func (m TableModel) rowNumber(line int) int {
+	if m.groupBy < 0 {
+		return line + 1
+	}
+
+	n := 0
+	for l := 0; l <= line && l < len(m.sortedIndex); l++ {
+		if !m.isGroupHeader(l) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// lineOfNumber() vrátí zobrazený řádek s číslem n, viz rowNumber()
+func (m TableModel) lineOfNumber(n int) int {
+	n = max(n, 1)
+	if m.groupBy < 0 {
+		return n - 1
+	}
+
+	last := -1
+	for line := range m.sortedIndex {
+		if m.isGroupHeader(line) {
+			continue
+		}
+		last = line
+		if n--; n == 0 {
+			break
+		}
+	}
+
+	return last
+}
+
+// numberWidth() vrátí šířku sloupečku s čísly řádků, 0 pokud se nezobrazuje
+func (m TableModel) numberWidth() int {
+	if !m.rowNumbers {
+		return 0
+	}
+
+	return len(strconv.Itoa(max(m.rowNumber(len(m.sortedIndex)-1), 1)))
+}
+
+// numberColumnWidth() vrátí šířku sloupečku s čísly řádků včetně oddělovače
+func (m TableModel) numberColumnWidth() int {
+	if nw := m.numberWidth(); nw > 0 {
+		return nw + 1
+	}
+
+	return 0
+}
+
+// prefixWidth() vrátí šířku sloupečků před obsahem (čísla řádků a checkboxy)
+// včetně jejich oddělovačů
+func (m TableModel) prefixWidth() int {
+	width := m.numberColumnWidth()
+	if cbWidth := m.checkboxWidth(); cbWidth > 0 {
+		width += cbWidth + 1
+	}
+
+	return width
+}
+
+// viewNumberCell() vykreslí buňku sloupečku s čísly řádků s textem text zarovnaným
+// doprava a oddělovač za ní, vysoké height řádků
+// Pokud se čísla řádků nezobrazují, vrátí ""
+func (m TableModel) viewNumberCell(text string, style, sepStyle lipgloss.Style, height int) string {
+	nw := m.numberWidth()
+	if nw == 0 {
+		return ""
+	}
+
+	cell := style.Width(nw).Height(height).Align(lipgloss.Right).Render(truncate(text, nw))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, cell, m.separator(-1, sepStyle, height))
+}
@@ -0,0 +1,176 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// StructOption je volba pro převod structů na řádky, viz FromStructs() a WithStructs()
+type StructOption func(*structConfig)
+
+type structConfig struct {
+	timeLayout string
+}
+
+// StructTimeLayout() nastaví formát pro hodnoty time.Time
+// Pokud není použito, je formát "2006-01-02 15:04:05"
+func StructTimeLayout(layout string) StructOption {
+	return func(c *structConfig) {
+		c.timeLayout = layout
+	}
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	stringerType = reflect.TypeFor[fmt.Stringer]()
+	errorType    = reflect.TypeFor[error]()
+)
+
+// FromStructs() převede slice structů (nebo ukazatelů na structy) na headery a řádky
+// Sloupečky jsou exportované položky structu (včetně položek vnořených structů),
+// tag `table:"Název"` přepíše header, `table:"-"` položku vynechá
+// Hodnoty se formátují podle typu: time.Time podle StructTimeLayout(), fmt.Stringer
+// a error svou metodou, čísla bez zbytečných nul, nil jako prázdná buňka
+// Headery se berou z typu, takže i prázdný slice vrátí headery
+// Pokud slice není slice/pole structů nebo struct nemá žádné exportované položky,
+// vrátí error
+func FromStructs(slice any, opts ...StructOption) (headers []string, rows [][]string, err error) {
+	cfg := structConfig{timeLayout: time.DateTime}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("FromStructs: očekáván slice structů, předán %T", slice)
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("FromStructs: očekáván slice structů, předán %T", slice)
+	}
+
+	var fields []reflect.StructField
+	for _, f := range reflect.VisibleFields(elem) {
+		if !f.IsExported() || f.Anonymous && f.Type.Kind() == reflect.Struct {
+			continue
+		}
+
+		name, ok := f.Tag.Lookup("table")
+		if name == "-" {
+			continue
+		}
+		if !ok || name == "" {
+			name = f.Name
+		}
+
+		headers = append(headers, name)
+		fields = append(fields, f)
+	}
+	if len(headers) == 0 {
+		return nil, nil, fmt.Errorf("FromStructs: %s nemá žádné exportované položky", elem)
+	}
+
+	rows = make([][]string, 0, v.Len())
+	for i := range v.Len() {
+		item := v.Index(i)
+		if item.Kind() == reflect.Pointer {
+			item = item.Elem()
+		}
+
+		row := make([]string, len(fields))
+		if item.IsValid() {
+			for n, f := range fields {
+				field, err := item.FieldByIndexErr(f.Index)
+				if err != nil {
+					continue
+				}
+				row[n] = formatValue(field, cfg)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return headers, rows, nil
+}
+
+// WithStructs() nastaví headery a obsah tabulky ze slice structů, viz FromStructs()
+// Pokud převod selže, zobrazí se chyba jako text prázdné tabulky (WithEmptyText())
+// s jediným sloupečkem "Struct", pro zpracování chyby použít FromStructs()
+func WithStructs(slice any, opts ...StructOption) func(*TableModel) {
+	return func(tm *TableModel) {
+		headers, rows, err := FromStructs(slice, opts...)
+		if err != nil {
+			tm.headers = []string{"Struct"}
+			tm.content = nil
+			tm.emptyText = err.Error()
+			return
+		}
+
+		tm.headers = headers
+		tm.content = rows
+	}
+}
+
+// formatValue() převede hodnotu položky structu na text buňky
+func formatValue(v reflect.Value, cfg structConfig) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		if v.Kind() == reflect.Pointer && v.Type() != reflect.PointerTo(timeType) &&
+			(v.Type().Implements(stringerType) || v.Type().Implements(errorType)) {
+			break
+		}
+		v = v.Elem()
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return ""
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(cfg.timeLayout)
+	}
+
+	if v.CanInterface() {
+		switch {
+		case v.Type().Implements(errorType):
+			return v.Interface().(error).Error()
+		case v.Type().Implements(stringerType):
+			return v.Interface().(fmt.Stringer).String()
+		case v.CanAddr() && reflect.PointerTo(v.Type()).Implements(stringerType):
+			return v.Addr().Interface().(fmt.Stringer).String()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+
+	return ""
+}
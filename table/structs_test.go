@@ -0,0 +1,77 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type server struct {
+	Name    string `table:"Název"`
+	Port    int
+	Load    float64
+	Started time.Time
+	secret  string
+	Skipped string `table:"-"`
+}
+
+func TestFromStructs(t *testing.T) {
+	started := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	servers := []*server{
+		{Name: "web", Port: 80, Load: 0.5, Started: started},
+		nil,
+	}
+
+	headers, rows, err := FromStructs(servers)
+	if err != nil {
+		t.Fatalf("FromStructs() vrátilo chybu: %v", err)
+	}
+
+	if want := []string{"Název", "Port", "Load", "Started"}; !reflect.DeepEqual(headers, want) {
+		t.Fatalf("headery = %q, chci %q", headers, want)
+	}
+
+	want := [][]string{
+		{"web", "80", "0.5", "2024-05-01 12:30:00"},
+		{"", "", "", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("řádky = %q, chci %q", rows, want)
+	}
+}
+
+func TestFromStructsEmptySlice(t *testing.T) {
+	headers, rows, err := FromStructs([]server{})
+	if err != nil {
+		t.Fatalf("FromStructs() vrátilo chybu: %v", err)
+	}
+	if len(headers) != 4 || len(rows) != 0 {
+		t.Fatalf("headery = %q, řádky = %q", headers, rows)
+	}
+}
+
+func TestWithStructsInvalid(t *testing.T) {
+	tests := map[string]any{
+		"nil":               nil,
+		"slice stringů":     []string{"a"},
+		"bez položek":       []struct{ a int }{{1}},
+		"prázdný slice any": []any{},
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := FromStructs(input); err == nil {
+				t.Fatal("FromStructs() nevrátilo chybu")
+			}
+
+			m := NewTableModel(WithStructs(input))
+			if len(m.GetHeaders()) == 0 {
+				t.Fatal("chybí náhradní headery")
+			}
+			if m.emptyText == "" {
+				t.Fatal("chyba se nezobrazí jako text prázdné tabulky")
+			}
+			_ = m.SetSize(40, 10).View()
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package table
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles jsou styly všech částí tabulky, viz WithStyles() a DefaultStyles()
+// Styly se při vykreslování jen kopírují (lipgloss.Style je hodnota), předané
+// styly se nikdy nemění
+type Styles struct {
+	Border         lipgloss.Style
+	Title          lipgloss.Style
+	ScrollBarBar   lipgloss.Style
+	ScrollBarSpace lipgloss.Style
+	Header         lipgloss.Style
+	Lines          lipgloss.Style
+	// AlternateLines se použije jen se zapnutým střídáním řádků, viz
+	// WithAlternateRowColors() a SetAlternateLinesStyle()
+	AlternateLines lipgloss.Style
+	SelectedLine   lipgloss.Style
+	SelectedCell   lipgloss.Style
+	MarkedLine     lipgloss.Style
+	Footer         lipgloss.Style
+	Filter         lipgloss.Style
+	Empty          lipgloss.Style
+	Match          lipgloss.Style
+	Group          lipgloss.Style
+	RowNumber      lipgloss.Style
+}
+
+// DefaultStyles() vrátí výchozí styly tabulky, vhodné jako základ pro úpravy
+// předávané do WithStyles()
+func DefaultStyles() Styles {
+	return Styles{
+		Border:         lipgloss.NewStyle().Bold(true),
+		Title:          lipgloss.NewStyle().Bold(true),
+		ScrollBarBar:   lipgloss.NewStyle().Bold(true),
+		ScrollBarSpace: lipgloss.NewStyle().Bold(true),
+		Header:         lipgloss.NewStyle().Bold(true),
+		Lines:          lipgloss.NewStyle(),
+		AlternateLines: lipgloss.NewStyle(),
+		SelectedLine: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#FFFFFF")).
+			Bold(true),
+		SelectedCell: lipgloss.NewStyle().Reverse(true),
+		MarkedLine: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFF00")).
+			Bold(true),
+		Footer:    lipgloss.NewStyle().Bold(true),
+		Filter:    lipgloss.NewStyle().Italic(true).Bold(true),
+		Empty:     lipgloss.NewStyle().Italic(true).Faint(true),
+		Group:     lipgloss.NewStyle().Bold(true).Underline(true),
+		RowNumber: lipgloss.NewStyle().Faint(true),
+		Match: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#FFFF00")),
+	}
+}
+
+// WithStyles() nastaví styly všech částí tabulky najednou
+// Nevyplněné styly jsou prázdné (bez barev a zvýraznění), pro změnu jen některých
+// stylů upravit DefaultStyles()
+// Barevné volby With*Colors() použité po WithStyles() přepíší příslušný styl
+func WithStyles(s Styles) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.setStyles(s)
+	}
+}
+
+// setStyles() nastaví styly ze s
+func (m *TableModel) setStyles(s Styles) {
+	m.borderStyle = s.Border
+	m.titleStyle = s.Title
+	m.scrollBarStyleBar = s.ScrollBarBar
+	m.scrollBarStyleSpace = s.ScrollBarSpace
+	m.headerStyle = s.Header
+	m.linesStyle = s.Lines
+	m.alternateLinesStyle = s.AlternateLines
+	m.selectedLineStyle = s.SelectedLine
+	m.selectedCellStyle = s.SelectedCell
+	m.markedLineStyle = s.MarkedLine
+	m.footerStyle = s.Footer
+	m.filterStyle = s.Filter
+	m.emptyStyle = s.Empty
+	m.matchStyle = s.Match
+	m.groupStyle = s.Group
+	m.rowNumberStyle = s.RowNumber
+}
+
+// GetStyles() vrátí aktuální styly tabulky
+func (m TableModel) GetStyles() Styles {
+	return Styles{
+		Border:         m.borderStyle,
+		Title:          m.titleStyle,
+		ScrollBarBar:   m.scrollBarStyleBar,
+		ScrollBarSpace: m.scrollBarStyleSpace,
+		Header:         m.headerStyle,
+		Lines:          m.linesStyle,
+		AlternateLines: m.alternateLinesStyle,
+		SelectedLine:   m.selectedLineStyle,
+		SelectedCell:   m.selectedCellStyle,
+		MarkedLine:     m.markedLineStyle,
+		Footer:         m.footerStyle,
+		Filter:         m.filterStyle,
+		Empty:          m.emptyStyle,
+		Match:          m.matchStyle,
+		Group:          m.groupStyle,
+		RowNumber:      m.rowNumberStyle,
+	}
+}
+
+// SetStyles() nastaví styly všech částí tabulky, viz WithStyles()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetStyles(s Styles) TableModel {
+	m.setStyles(s)
+	m = m.SetFilterStyle(s.Filter)
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetBorderStyle() nastaví styl okraje
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetBorderStyle(style lipgloss.Style) TableModel {
+	m.borderStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetTitleStyle() nastaví styl titulku
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetTitleStyle(style lipgloss.Style) TableModel {
+	m.titleStyle = style
+
+	return m
+}
+
+// SetScrollBarStyle() nastaví styl jezdce (bar) a dráhy (space) scrollbaru
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetScrollBarStyle(bar, space lipgloss.Style) TableModel {
+	m.scrollBarStyleBar = bar
+	m.scrollBarStyleSpace = space
+
+	return m
+}
+
+// SetHeaderStyle() nastaví styl headerů
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetHeaderStyle(style lipgloss.Style) TableModel {
+	m.headerStyle = style
+
+	return m
+}
+
+// SetLinesStyle() nastaví styl řádků (při střídání řádků styl sudých řádků)
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetLinesStyle(style lipgloss.Style) TableModel {
+	m.linesStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetAlternateLinesStyle() nastaví styl lichých řádků a zapne střídání řádků,
+// viz WithAlternateRowColors()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetAlternateLinesStyle(style lipgloss.Style) TableModel {
+	m.alternateLinesStyle = style
+	m.alternateLines = true
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetSelectedLineStyle() nastaví styl vybraného řádku
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetSelectedLineStyle(style lipgloss.Style) TableModel {
+	m.selectedLineStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetSelectedCellStyle() nastaví styl vybrané buňky, viz WithColumnCursor()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetSelectedCellStyle(style lipgloss.Style) TableModel {
+	m.selectedCellStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetMarkedLineStyle() nastaví styl označených řádků
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetMarkedLineStyle(style lipgloss.Style) TableModel {
+	m.markedLineStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetFooterStyle() nastaví styl patičky
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetFooterStyle(style lipgloss.Style) TableModel {
+	m.footerStyle = style
+
+	return m
+}
+
+// SetFilterStyle() nastaví styl řádku s filtrem
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetFilterStyle(style lipgloss.Style) TableModel {
+	m.filterStyle = style
+	m.filterInput.TextStyle = style
+	m.filterInput.PromptStyle = style
+	m.filterInput.Cursor.Style = style
+
+	return m
+}
+
+// SetEmptyStyle() nastaví styl textu prázdné tabulky a textu načítání
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetEmptyStyle(style lipgloss.Style) TableModel {
+	m.emptyStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetMatchStyle() nastaví styl zvýraznění shody s filtrem
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetMatchStyle(style lipgloss.Style) TableModel {
+	m.matchStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetGroupStyle() nastaví styl řádků skupin, viz SetGroupBy()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetGroupStyle(style lipgloss.Style) TableModel {
+	m.groupStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetRowNumberStyle() nastaví styl čísel řádků, viz WithRowNumbers()
+// Vybraný řádek má číslo ve stylu vybraného řádku
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetRowNumberStyle(style lipgloss.Style) TableModel {
+	m.rowNumberStyle = style
+	m.rev = lastRev.Add(1)
+
+	return m
+}
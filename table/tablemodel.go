@@ -5,16 +5,46 @@ package table
 
 import (
 	"fmt"
+	"io"
+	"maps"
+	"math"
+	"reflect"
 	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
 
 	"golang.org/x/text/collate"
 	"golang.org/x/text/language"
 
 	"github.com/acarl005/stripansi"
+	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+	"github.com/tomaspantlik/crapmodels/checkbox"
+)
+
+// ScrollBarMode určuje, kdy se zobrazuje scrollbar, viz WithScrollBar()
+type ScrollBarMode int
+
+const (
+	ScrollBarAuto   ScrollBarMode = iota // zobrazit, pokud se obsah nevejde do okna
+	ScrollBarAlways                      // zobrazit vždy
+	ScrollBarNever                       // nezobrazovat
+)
+
+// Edge určuje okraj tabulky, ke kterému je připnutý řádek, viz PinRow()
+type Edge int
+
+const (
+	EdgeTop    Edge = iota // pod headery
+	EdgeBottom             // nad patičkou
 )
 
 type SortOrder int
@@ -35,6 +65,75 @@ func (so SortOrder) String() string {
 	return sortOrderName[so]
 }
 
+// FilterScorer je rozhraní pro ohodnocení shody filtru s hodnotou buňky při
+// fuzzy filtrování (WithFuzzyFilter())
+// Score() vrací skóre shody (vyšší je lepší) a ok == false, pokud hodnota filtru
+// vůbec neodpovídá
+type FilterScorer interface {
+	Score(filter, value string) (score int, ok bool)
+}
+
+// FuzzyScorer je výchozí FilterScorer
+// Hledá znaky filtru jako podposloupnost hodnoty bez ohledu na velikost písmen,
+// zvýhodňuje navazující znaky a znaky na začátku slova, penalizuje mezery mezi nimi
+type FuzzyScorer struct{}
+
+// Score() implementuje FilterScorer
+func (FuzzyScorer) Score(filter, value string) (int, bool) {
+	f := []rune(strings.ToLower(filter))
+	v := []rune(strings.ToLower(value))
+
+	if len(f) == 0 {
+		return 0, true
+	}
+
+	var (
+		score int
+		fi    int
+		last  = -1
+	)
+
+	for vi, r := range v {
+		if fi == len(f) {
+			break
+		}
+		if r != f[fi] {
+			continue
+		}
+
+		score++
+		if last >= 0 && vi == last+1 {
+			score += 5
+		} else if last >= 0 {
+			score -= vi - last - 1
+		}
+		if vi == 0 || !unicode.IsLetter(v[vi-1]) && !unicode.IsDigit(v[vi-1]) {
+			score += 3
+		}
+
+		last = vi
+		fi++
+	}
+
+	if fi < len(f) {
+		return 0, false
+	}
+
+	return score, true
+}
+
+// RowStyleFunc je funkce, která vrací styl řádku podle jeho obsahu
+// rowIndex je index řádku v celém obsahu (GetContent()), bez ohledu na filtr a řazení
+type RowStyleFunc func(rowIndex int, row []string) lipgloss.Style
+
+// BottomTextFunc je funkce, která vrací text do spodního okraje tabulky,
+// viz WithBottomTextFunc()
+type BottomTextFunc func(m TableModel) string
+
+// FooterFunc je funkce, která z řádků tabulky spočítá buňky patičky (např. součty)
+// rows jsou řádky po filtrování
+type FooterFunc func(rows [][]string) []string
+
 var (
 	// DefaultKeys je výchozí mapování klávesových zkratek
 	DefaultKeys = Keys{
@@ -59,9 +158,101 @@ var (
 		Filter1:         "f",
 		Filter2:         "/",
 		ClearFilter1:    tea.KeyCtrlF.String(),
+		Choose1:         tea.KeyEnter.String(),
+		ToggleMark1:     " ",
+		SelectAll1:      tea.KeyCtrlA.String(),
+		ScrollLeft1:     tea.KeyLeft.String(),
+		ScrollRight1:    tea.KeyRight.String(),
+		CollapseGroup1:  "z",
+		ExpandGroups1:   "Z",
+		ToggleGroup1:    "o",
+		Detail1:         "v",
+		Yank1:           "y",
+		YankMarked1:     "Y",
+		ColumnLeft1:     "h",
+		ColumnRight1:    "l",
+		Edit1:           "e",
+		Delete1:         "d",
+		GoTo1:           ":",
 	}
 )
 
+// RowChosenMsg je zpráva, kterou vrací tea.Cmd z Update() po stisku klávesy Choose
+// Index je index řádku tak, jak je zobrazený (po filtrování a řazení)
+// ContentIndex je index řádku v původním obsahu (GetContent())
+type RowChosenMsg struct {
+	Index        int
+	ContentIndex int
+	Row          []string
+}
+
+// RowDeleteRequestedMsg je zpráva, kterou vrací tea.Cmd z Update() po stisku
+// klávesy Delete, tabulka sama nic nemaže, smazání potvrdí hlavní model přes
+// ConfirmDelete()
+// Index je index vybraného řádku v obsahu (GetContent()) a Row jeho obsah
+// Pokud jsou označené řádky, jsou Index -1 a Row nil a mažou se označené řádky
+// Indexes a Rows obsahují všechny řádky ke smazání (seřazené podle indexu)
+type RowDeleteRequestedMsg struct {
+	Index   int
+	Row     []string
+	Indexes []int
+	Rows    [][]string
+}
+
+// loadingTickMsg posouvá animaci načítání, viz SetLoading()
+// id rozlišuje tabulky, tag rozlišuje jednotlivá zapnutí načítání
+type loadingTickMsg struct {
+	id  int64
+	tag int
+}
+
+// loadingFrames jsou snímky animace načítání
+var loadingFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// loadingInterval je doba mezi snímky animace načítání
+const loadingInterval = 100 * time.Millisecond
+
+// lastID je poslední přidělené id tabulky
+var lastID atomic.Int64
+
+// lastRev je poslední přidělená revize vykreslovaného stavu tabulky
+// Revize se mění při každé změně obsahu, řazení, označení, sloupečků nebo stylů
+// řádků a je unikátní napříč kopiemi modelu, které sdílejí renderCache
+var lastRev atomic.Int64
+
+// bodyKey popisuje stav, ze kterého se vykresluje tělo tabulky
+type bodyKey struct {
+	rev                       int64
+	width, rows               int
+	scrolledTop, selectedLine int
+	selectedCol               int
+	colOffset                 int
+	loading                   bool
+	loadingFrame              int
+}
+
+// renderCache drží naposledy vykreslené tělo tabulky, viz cachedBody()
+type renderCache struct {
+	key   bodyKey
+	body  string
+	valid bool
+}
+
+// doubleClickInterval je nejdelší doba mezi dvěma kliknutími, které se berou jako dvojklik
+const doubleClickInterval = 500 * time.Millisecond
+
+// SelectionChangedMsg je zpráva, kterou vrací tea.Cmd z Update(), pokud se
+// zpracováním klávesy změnil vybraný řádek
+// OldIndex a NewIndex jsou indexy zobrazených řádků (po filtrování a řazení),
+// ContentIndex je index nově vybraného řádku v původním obsahu (-1 pro prázdnou tabulku)
+// Row je nově vybraný řádek, pro prázdnou tabulku nil
+type SelectionChangedMsg struct {
+	OldIndex     int
+	NewIndex     int
+	ContentIndex int
+	Row          []string
+}
+
 // Keys je typ pro definování klávesových zkratek
 // Vychází z bubbletea.KeyMsg.String()
 // Každá akce může mít více klávesových zkratek (SelectLineDown1, SelectLineDown2, ...)
@@ -97,30 +288,168 @@ type Keys struct {
 	ClearFilter1    string
 	ClearFilter2    string
 	ClearFilter3    string
+	Choose1         string
+	Choose2         string
+	Choose3         string
+	ToggleMark1     string
+	ToggleMark2     string
+	ToggleMark3     string
+	SelectAll1      string
+	SelectAll2      string
+	SelectAll3      string
+	ScrollLeft1     string
+	ScrollLeft2     string
+	ScrollLeft3     string
+	ScrollRight1    string
+	ScrollRight2    string
+	ScrollRight3    string
+	CollapseGroup1  string
+	CollapseGroup2  string
+	CollapseGroup3  string
+	ExpandGroups1   string
+	ExpandGroups2   string
+	ExpandGroups3   string
+	ToggleGroup1    string
+	ToggleGroup2    string
+	ToggleGroup3    string
+	Detail1         string
+	Detail2         string
+	Detail3         string
+	Yank1           string
+	Yank2           string
+	Yank3           string
+	YankMarked1     string
+	YankMarked2     string
+	YankMarked3     string
+	ColumnLeft1     string
+	ColumnLeft2     string
+	ColumnLeft3     string
+	ColumnRight1    string
+	ColumnRight2    string
+	ColumnRight3    string
+	Edit1           string
+	Edit2           string
+	Edit3           string
+	Delete1         string
+	Delete2         string
+	Delete3         string
+	GoTo1           string
+	GoTo2           string
+	GoTo3           string
+}
+
+// contains() vrátí true, pokud je key některá z kláves
+func (k Keys) contains(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	v := reflect.ValueOf(k)
+	for i := range v.NumField() {
+		if v.Field(i).String() == key {
+			return true
+		}
+	}
+
+	return false
 }
 
 // TableModel je model pro použití v bubbletea aplikaci
 // Pro interakci s modelem se používají výhradně receiver funkce, které vracejí
 // zpět upravený model
 type TableModel struct {
+	id            int64
+	rev           int64
+	cache         *renderCache
 	width, height int
-
-	title    string
-	headers  []string
-	content  [][]string
-	colSizes []int
+	posX, posY    int
+	wheelDelta    int
+
+	autoSize         bool
+	offsetW, offsetH int
+
+	detailOpen   bool
+	detailScroll int
+	osc52        bool
+	osc52Writer  io.Writer
+
+	editable     bool
+	editableCols []int
+	resortOnEdit bool
+	editActive   bool
+	editRow      int
+	editCol      int
+	editInput    textinput.Model
+
+	scrollBar        ScrollBarMode
+	percentIndicator bool
+	pagination       bool
+	lastClick        time.Time
+	lastClickLine    int
+
+	title       string
+	headers     []string
+	content     [][]string
+	footer      []string
+	footerFunc  FooterFunc
+	colSizes    []int
+	colMinSizes []int
+	colMaxSizes []int
+	maxColWidth int
+	colFlex     []float64
+	natural     []int
+
+	colFormatters map[int]ColFormatter
+	colParsers    map[int]ColParser
+	colCompares   map[int]ColCompare
+	fitColumns    bool
+
+	colOrder         []int
+	groupBy          int
+	collapsed        map[string]bool
+	keyColumn        int
+	horizontalScroll bool
+	cellWrap         bool
+	showHeaders      bool
+	border           bool
+	rowNumbers       bool
+	gotoActive       bool
+	gotoInput        textinput.Model
+	colSeparators    bool
+	headerTruncation bool
+	maxRows          int
+	dropped          int
+	separatorsAfter  []int
+	colOffset        int
 
 	keys Keys
 
-	selectedLine int
-	scrolledTop  int
+	selectedLine     int
+	selectedCol      int
+	columnCursor     bool
+	scrolledTop      int
+	emitOnSet        bool
+	pendingSelection *SelectionChangedMsg
+
+	marked          map[int]bool
+	pinned          map[int]Edge
+	pinnedTop       int
+	pinnedBottom    int
+	checkableRows   bool
+	checkboxSymbols checkbox.Symbols
 
 	filter          string
 	filterColums    []int
 	filteredContent [][]string
+	filteredIndex   []int
+	fuzzyFilter     bool
+	filterScorer    FilterScorer
 	sortByCol       int
 	sortOrder       SortOrder
+	sortAscSymbol   string
+	sortDescSymbol  string
 	sortedContent   [][]string
+	sortedIndex     []int
 
 	filterInput          textinput.Model
 	filterPrev           string
@@ -133,8 +462,39 @@ type TableModel struct {
 	scrollBarStyleSpace lipgloss.Style
 	headerStyle         lipgloss.Style
 	linesStyle          lipgloss.Style
+	alternateLinesStyle lipgloss.Style
+	alternateLines      bool
+	rowStyleFunc        RowStyleFunc
+	bottomTextFunc      BottomTextFunc
+	bottomTextLeft      bool
+	rowCounter          bool
+	rowCounterFormat    func(n int) string
 	selectedLineStyle   lipgloss.Style
+	selectedCellStyle   lipgloss.Style
+	markedLineStyle     lipgloss.Style
+	footerStyle         lipgloss.Style
 	filterStyle         lipgloss.Style
+	emptyStyle          lipgloss.Style
+	matchStyle          lipgloss.Style
+	groupStyle          lipgloss.Style
+	rowNumberStyle      lipgloss.Style
+
+	emptyText string
+
+	loading      bool
+	loadingText  string
+	loadingFrame int
+	loadingTag   int
+	pendingTick  bool
+
+	rowLoader       RowLoader
+	loaderLimit     int
+	loaderThreshold int
+	loaderTotal     int
+	loaderOffset    int
+	loaderTag       int
+	loaderPending   bool
+	loaderDone      bool
 }
 
 // NewTableModel() je funkce pro vytvoření nového TableModelu
@@ -142,21 +502,30 @@ type TableModel struct {
 // Pro nastavení vlastností modelu použít jako parametry funkce WithKeys a další
 func NewTableModel(options ...func(*TableModel)) TableModel {
 	m := TableModel{
-		keys:                DefaultKeys,
-		borderType:          lipgloss.RoundedBorder(),
-		borderStyle:         lipgloss.NewStyle().Bold(true),
-		titleStyle:          lipgloss.NewStyle().Bold(true),
-		scrollBarStyleBar:   lipgloss.NewStyle().Bold(true),
-		scrollBarStyleSpace: lipgloss.NewStyle().Bold(true),
-		headerStyle:         lipgloss.NewStyle().Bold(true),
-		linesStyle:          lipgloss.NewStyle(),
-		selectedLineStyle: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#000000")).
-			Background(lipgloss.Color("#FFFFFF")).
-			Bold(true),
-		filterStyle: lipgloss.NewStyle().Italic(true).Bold(true),
-		sortOrder:   SortUnsorted,
+		id:               lastID.Add(1),
+		cache:            &renderCache{},
+		keys:             DefaultKeys,
+		borderType:       lipgloss.RoundedBorder(),
+		loadingText:      "Načítání…",
+		sortOrder:        SortUnsorted,
+		sortAscSymbol:    "▲",
+		sortDescSymbol:   "▼",
+		filterScorer:     FuzzyScorer{},
+		checkboxSymbols:  checkbox.DefaultSymbols,
+		wheelDelta:       3,
+		keyColumn:        -1,
+		groupBy:          -1,
+		percentIndicator: true,
+		osc52:            true,
+		resortOnEdit:     true,
+		showHeaders:      true,
+		border:           true,
+		colSeparators:    true,
+		headerTruncation: true,
+		loaderLimit:      100,
+		loaderThreshold:  20,
 	}
+	m.setStyles(DefaultStyles())
 
 	for _, opt := range options {
 		opt(&m)
@@ -167,12 +536,20 @@ func NewTableModel(options ...func(*TableModel)) TableModel {
 		panic("nejsou nastaveny headry!")
 	}
 
+	var dropped int
+	m.content, dropped = limitRows(m.content, m.maxRows)
+	m.dropped += dropped
+
 	if len(m.filterColums) == 0 {
 		for i := range len(m.headers) {
 			m.filterColums = append(m.filterColums, i)
 		}
 	}
 
+	m = m.refreshContent()
+	m.loaderPending = m.rowLoader != nil && len(m.content) == 0
+	m.loaderOffset = len(m.content)
+
 	m.filterInput = textinput.New()
 	m.filterInput.Prompt = " Filtr: "
 	m.filterInput.TextStyle = m.filterStyle
@@ -213,7 +590,59 @@ func WithHeaders(headers ...string) func(*TableModel) {
 func WithContent(content ...[]string) func(*TableModel) {
 	return func(tm *TableModel) {
 		tm.content = content
-		tm.filteredContent = content
+	}
+}
+
+// WithKeyColumn() nastaví sloupeček col, jehož hodnota identifikuje řádek
+// SetContent() pak po výměně obsahu znovu vybere řádek se stejnou hodnotou klíče
+// a zachová jeho pozici v okně, označené řádky se přenesou podle klíče
+// Pokud vybraný klíč v novém obsahu není, zůstane vybraný řádek na stejném indexu
+// (omezený na rozsah tabulky)
+// Pokud klíče nejsou unikátní, vybere se první zobrazený řádek s daným klíčem
+// a označí se všechny řádky, jejichž klíč byl označený
+// Pokud není použito, SetContent() vybraný řádek nepřepočítává a označení ruší
+func WithKeyColumn(col int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.keyColumn = col
+	}
+}
+
+// WithGroupBy() seskupí řádky podle hodnoty sloupečku col
+// Před každou skupinou se zobrazí řádek s názvem skupiny a počtem řádků,
+// na který nejde přesunout výběr, skupiny jsou v pořadí svého prvního řádku
+// (pro seřazení skupin stačí řadit podle sloupečku col)
+// Klávesa CollapseGroup sbalí skupinu vybraného řádku, ExpandGroups rozbalí
+// všechny skupiny, ToggleGroup sbalí/rozbalí skupinu u kurzoru (viz
+// ToggleGroupAtCursor()), kliknutí myší na řádek skupiny ji sbalí/rozbalí
+func WithGroupBy(col int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.groupBy = col
+	}
+}
+
+// WithGroupColors() nastaví barvy řádků se skupinami, viz WithGroupBy()
+func WithGroupColors(fg, bg lipgloss.Color) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.groupStyle = tm.groupStyle.
+			Foreground(fg).
+			Background(bg)
+	}
+}
+
+// WithFooter() nastaví patičku tabulky - řádek zobrazený pod řádky tabulky, který
+// se neposouvá (např. pro součty)
+// Pokud není použito, patička se nezobrazuje
+func WithFooter(cells ...string) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.footer = cells
+	}
+}
+
+// WithFooterFunc() nastaví funkci, která spočítá patičku z řádků tabulky
+// Patička se přepočítá při každé změně obsahu nebo filtru
+func WithFooterFunc(f FooterFunc) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.footerFunc = f
 	}
 }
 
@@ -226,6 +655,292 @@ func WithColSizes(s ...int) func(*TableModel) {
 	}
 }
 
+// WithColFormatter() nastaví formátovač hodnot sloupečku col, viz ColFormatter
+// Formátovač se použije jen při vykreslení (a výpočtu šířky sloupečku),
+// řazení, filtrování, GetContent() i exporty pracují s původní hodnotou (pro
+// řazení čísel a časů viz WithColCompare())
+func WithColFormatter(col int, f ColFormatter) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colFormatters = maps.Clone(tm.colFormatters)
+		if tm.colFormatters == nil {
+			tm.colFormatters = make(map[int]ColFormatter)
+		}
+		tm.colFormatters[col] = f
+	}
+}
+
+// WithColFormatters() nastaví formátovače hodnot pro více sloupečků najednou,
+// klíčem je index sloupečku, viz WithColFormatter()
+func WithColFormatters(formatters map[int]ColFormatter) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colFormatters = maps.Clone(tm.colFormatters)
+		if tm.colFormatters == nil {
+			tm.colFormatters = make(map[int]ColFormatter)
+		}
+		maps.Copy(tm.colFormatters, formatters)
+	}
+}
+
+// WithColCompare() nastaví porovnání hodnot sloupečku col pro řazení, viz
+// ColCompare, CompareNumeric() a CompareTime()
+// Pokud není použito, řadí se hodnoty jako text podle české abecedy
+func WithColCompare(col int, cmp ColCompare) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colCompares = maps.Clone(tm.colCompares)
+		if tm.colCompares == nil {
+			tm.colCompares = make(map[int]ColCompare)
+		}
+		tm.colCompares[col] = cmp
+	}
+}
+
+// WithColParser() nastaví převod textu z editoru buňky na hodnotu obsahu pro
+// sloupeček col, viz ColParser a WithEditable()
+// S převodem se v editoru zobrazí hodnota upravená formátovačem sloupečku
+// (WithColFormatter()) a potvrzený text se převede zpět, bez převodu se
+// upravuje původní hodnota
+func WithColParser(col int, p ColParser) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colParsers = maps.Clone(tm.colParsers)
+		if tm.colParsers == nil {
+			tm.colParsers = make(map[int]ColParser)
+		}
+		tm.colParsers[col] = p
+	}
+}
+
+// WithColMinSizes() nastaví minimální šířku automatických sloupečků
+// Pokud je velikost == 0, tak minimum není omezené
+// Minimum se dodrží, pokud se sloupečky vejdou do šířky tabulky
+func WithColMinSizes(s ...int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colMinSizes = s
+	}
+}
+
+// WithColMaxSizes() nastaví maximální šířku automatických sloupečků
+// Pokud je velikost == 0, tak maximum není omezené
+// Pokud by tabulka se všemi sloupečky na maximu nevyplnila šířku okna, zbylé
+// místo dostane poslední automatický sloupeček
+func WithColMaxSizes(s ...int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colMaxSizes = s
+	}
+}
+
+// WithMaxColWidth() nastaví maximální šířku všech automatických sloupečků,
+// delší hodnoty se zkrátí, ušetřené místo dostanou ostatní sloupečky
+// Sloupečky s pevnou šířkou (WithColSizes()) se neomezují, s WithColMaxSizes()
+// platí menší z obou maxim
+// Pokud není použito nebo je n == 0, šířka není omezená
+func WithMaxColWidth(n int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.maxColWidth = n
+	}
+}
+
+// WithColFlex() nastaví poměrné šířky sloupečků, např. WithColFlex(3, 1, 6) dá
+// sloupečkům 30 %, 10 % a 60 % místa
+// Místo, které zbude po sloupečcích s pevnou šířkou (WithColSizes()), se rozdělí
+// mezi sloupečky s vahou > 0 v poměru vah tak, aby součet přesně odpovídal šířce
+// tabulky, automatické sloupečky bez váhy dostanou šířku svého obsahu
+// Šířky se přepočítávají při každé změně velikosti
+func WithColFlex(weights ...float64) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colFlex = weights
+	}
+}
+
+// WithFitColumns() nastaví rozdělení šířky mezi automatické sloupečky podle
+// šířky jejich obsahu
+// Pokud se obsah vejde, rozdělí se volné místo v poměru šířek obsahu, jinak si
+// úzké sloupečky ponechají svoji šířku a zbytek se rozdělí mezi široké sloupečky
+// Pokud není použito, dostanou automatické sloupečky stejnou šířku
+func WithFitColumns(fit bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.fitColumns = fit
+	}
+}
+
+// WithHorizontalScroll() zapne vodorovné posouvání sloupečků
+// Sloupečky se nezužují pod šířku svého obsahu (omezenou WithColMinSizes() a
+// WithColMaxSizes()), zobrazí se jen ty, které se vejdou, a klávesy ScrollLeft
+// a ScrollRight posouvají první zobrazený sloupeček
+// Skryté sloupečky vlevo/vpravo jsou naznačené šipkami v horním okraji
+// Pokud není použito, zobrazují se vždy všechny sloupečky
+func WithHorizontalScroll(scroll bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.horizontalScroll = scroll
+	}
+}
+
+// WithCellWrap() zapne zalamování dlouhých hodnot v buňkách
+// Řádek tabulky pak může zabírat více řádků terminálu, posouvání, stránkování,
+// scrollbar i procenta se počítají v řádcích terminálu
+// Pokud není použito, dlouhé hodnoty se zkracují a každý řádek tabulky zabírá
+// jeden řádek terminálu
+func WithCellWrap(wrap bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.cellWrap = wrap
+	}
+}
+
+// WithBorder() nastaví, jestli se kolem tabulky vykresluje okraj, bez okraje
+// připadne jeho místo řádkům a sloupečkům obsahu, titulek a ukazatele pozice se
+// nezobrazují a scrollbar je v posledním sloupci tabulky (s ScrollBarNever se
+// tento sloupec nevyhrazuje)
+// Pokud není použito, okraj se vykresluje
+func WithBorder(border bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.border = border
+	}
+}
+
+// WithShowHeaders() nastaví, jestli se zobrazuje řádek s headery, bez headerů
+// připadne jejich řádek řádkům obsahu (řadit jde jen klávesami)
+// Pokud není použito, headery se zobrazují
+func WithShowHeaders(show bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.showHeaders = show
+	}
+}
+
+// WithHeaderTruncation() nastaví, jestli se dlouhé headery zkracují na šířku
+// sloupečku (s "…" na konci), při false se headery zalamují na dva řádky, což
+// ubere jeden řádek obsahu
+// Symbol řazení zůstává vždy vidět
+// Pokud není použito, headery se zkracují
+func WithHeaderTruncation(truncate bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.headerTruncation = truncate
+	}
+}
+
+// WithColumnSeparators() nastaví, jestli se mezi sloupečky vykresluje svislá čára
+// okraje (barvou okraje), při false zůstane mezi sloupečky mezera, šířky
+// sloupečků se nemění
+// Pokud není použito, oddělovače se vykreslují
+func WithColumnSeparators(show bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.colSeparators = show
+	}
+}
+
+// WithSeparatorsAfter() nastaví, že se oddělovač vykreslí jen za sloupečky cols
+// (indexy v headerech), mezi ostatními sloupečky zůstane mezera
+// Funguje jen se zapnutými oddělovači, viz WithColumnSeparators()
+func WithSeparatorsAfter(cols ...int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.separatorsAfter = cols
+	}
+}
+
+// WithMaxRows() omezí počet řádků obsahu na n, WithContent(), SetContent(),
+// AppendContent() i InsertRow() zahodí nejstarší řádky (ze začátku obsahu) nad
+// tento počet, viz GetDroppedCount()
+// Pokud není použito nebo je n <= 0, počet řádků není omezený
+func WithMaxRows(n int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.maxRows = n
+	}
+}
+
+// WithAutoSize() nastaví, že tabulka přebírá velikost terminálu z tea.WindowSizeMsg
+// (zmenšenou o WithSizeOffset()), při každé změně se přepočítají šířky sloupečků
+// a omezí posun pohledu a vybraný řádek
+// Pokud není použito, velikost se nastavuje jen přes SetSize()
+func WithAutoSize(auto bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.autoSize = auto
+	}
+}
+
+// WithSizeOffset() nastaví, o kolik je tabulka s WithAutoSize(true) užší (dw)
+// a nižší (dh) než terminál, např. pro místo na další modely
+func WithSizeOffset(dw, dh int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.offsetW, tm.offsetH = dw, dh
+	}
+}
+
+// WithColumnCursor() zapne kurzor sloupečku, vybraná buňka (průsečík vybraného
+// řádku a sloupečku) je zvýrazněná a kurzor se posouvá klávesami ColumnLeft
+// a ColumnRight, s WithHorizontalScroll(true) se pohled posouvá za kurzorem
+// S kurzorem ukazuje detail (OpenDetail()) jen vybranou buňku a Yank() kopíruje
+// jen vybranou buňku
+func WithColumnCursor(enabled bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.columnCursor = enabled
+	}
+}
+
+// WithOSC52() nastaví, jestli Yank() a YankMarked() kopírují do schránky pomocí
+// sekvence OSC 52 zapsané do WithOSC52Writer(), pro terminály bez podpory vypnout,
+// tea.Cmd pak místo kopírování vrací RowYankedMsg a kopírování zajistí hlavní model
+// Pokud není použito, je OSC 52 zapnuté, bez WithOSC52Writer() se ale také vrací
+// RowYankedMsg
+func WithOSC52(enabled bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.osc52 = enabled
+	}
+}
+
+// WithOSC52Writer() nastaví, kam Yank() a YankMarked() zapisují sekvenci OSC 52
+// Sekvence se zapisuje z tea.Cmd souběžně s vykreslováním bubbletea, proto
+// nepředávat os.Stdout programu, ale jiný výstup terminálu (např. otevřený /dev/tty)
+// Pokud není použito, sekvence se nezapisuje a tea.Cmd vrací RowYankedMsg,
+// kopírování pak zajistí hlavní model
+func WithOSC52Writer(w io.Writer) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.osc52Writer = w
+	}
+}
+
+// WithPosition() nastaví pozici levého horního rohu tabulky na obrazovce
+// Používá se pro zpracování událostí myši, události mimo tabulku se posílají dál
+// Pokud není použito, je tabulka v levém horním rohu obrazovky
+func WithPosition(x, y int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.posX, tm.posY = x, y
+	}
+}
+
+// WithMouseWheelDelta() nastaví, o kolik řádků posune pohled kolečko myši
+// Pokud není použito, posouvá se o 3 řádky
+func WithMouseWheelDelta(delta int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.wheelDelta = delta
+	}
+}
+
+// WithScrollBar() nastaví, kdy se zobrazuje scrollbar v pravém okraji
+// Pokud není použito, je nastaveno ScrollBarAuto
+func WithScrollBar(mode ScrollBarMode) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.scrollBar = mode
+	}
+}
+
+// WithPagination() zapne stránkování místo plynulého posouvání
+// Pohled je vždy na celé stránce (počet řádků, které se vejdou do okna), PageDown
+// a PageUp přechází po stránkách, pohyb výběru za okraj stránky přejde na další
+// stránku, v okraji se místo procent zobrazuje číslo stránky a scrollbar se skryje
+// S WithCellWrap(true) se stránky počítají v řádcích obsahu, vysoké řádky se
+// na konci stránky oříznou
+func WithPagination(pagination bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.pagination = pagination
+	}
+}
+
+// WithPercentIndicator() zapne/vypne zobrazení procent posunu ve spodním okraji
+// Pokud není použito, procenta se zobrazují
+func WithPercentIndicator(show bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.percentIndicator = show
+	}
+}
+
 // WithBorderType() nastaví typ okraje okna
 // Pokud není použito, je nastaven výchozí styl lipgloss.RoundedBorder()
 func WithBorderType(borderStyle lipgloss.Border) func(*TableModel) {
@@ -298,659 +1013,3847 @@ func WithSelectedLineColors(fg, bg lipgloss.Color) func(*TableModel) {
 	}
 }
 
-// WithFilterColors() nastaví barvy pro filtr
-func WithFilterColors(fg, bg lipgloss.Color) func(*TableModel) {
+// WithSelectedCellColors() nastaví barvy vybrané buňky při WithColumnCursor(true)
+// Nenastavené vlastnosti se převezmou ze stylu vybraného řádku
+// Pokud není použito, má vybraná buňka prohozené barvy vybraného řádku
+func WithSelectedCellColors(fg, bg lipgloss.Color) func(*TableModel) {
 	return func(tm *TableModel) {
-		tm.filterStyle = lipgloss.NewStyle().
+		tm.selectedCellStyle = lipgloss.NewStyle().
 			Foreground(fg).Background(bg).
-			Italic(true).Bold(true)
+			Bold(true)
 	}
 }
 
-// WithFilterColums() nastaví, podle kterých sloupečků se má filtrovat obsah pomocí SetFilter()
-// Pokud není nastaveno, filtruje podle všech sloupečků
-func WithFilterColums(cols ...int) func(*TableModel) {
+// WithAlternateRowColors() nastaví střídavé barvy sudých a lichých řádků
+// Sudé/liché řádky se počítají podle zobrazení (po filtrování a řazení), vybraný
+// a označený řádek mají přednost
+// Přepíše barvy nastavené pomocí WithLinesColors()
+func WithAlternateRowColors(evenFg, evenBg, oddFg, oddBg lipgloss.Color) func(*TableModel) {
 	return func(tm *TableModel) {
-		tm.filterColums = cols
+		tm.linesStyle = lipgloss.NewStyle().
+			Foreground(evenFg).Background(evenBg)
+		tm.alternateLinesStyle = lipgloss.NewStyle().
+			Foreground(oddFg).Background(oddBg)
+		tm.alternateLines = true
 	}
 }
 
-// Update() je standardní definice pro bubbletea
-// Návratové proměné jsou rozšířené o bubbletea.Msg
-//
-// Použití v hlavním modelu - na začátku funkce Update() zavolat:
-//
-//	m.table, cmd, msg = m.table.Update(msg)
-//
-// Pokud je předána klávesová zkratka, která je v modelu zaregistrovaná pro ovládání,
-// model si ji přebere a nepošle ji dál. Ostatní tea.KeyMsg i tea.Msg posílá zpět
-func (m TableModel) Update(msg tea.Msg) (TableModel, tea.Cmd, tea.Msg) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
+// WithBottomTextFunc() nastaví funkci pro vlastní text ve spodním okraji (např.
+// počet označených řádků), text se vykreslí stylem titulku vpravo vedle údajů
+// o pozici a zkrátí se tak, aby se vešel
+// Pokud se text nevejde vedle procent, procenta se nezobrazí
+// Bez okraje (WithBorder(false)) se text nezobrazuje
+func WithBottomTextFunc(f BottomTextFunc) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.bottomTextFunc = f
+	}
+}
 
-		if m.filterInputDisplayed {
-			var cmd tea.Cmd
+// WithBottomTextPosition() nastaví, jestli je text z WithBottomTextFunc() u levého
+// (lipgloss.Left) nebo pravého (lipgloss.Right) okraje
+// Pokud není použito, je text vpravo
+func WithBottomTextPosition(pos lipgloss.Position) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.bottomTextLeft = pos == lipgloss.Left
+	}
+}
 
-			switch msg.Type {
-			case tea.KeyEsc:
-				m.filterInputDisplayed = false
+// WithRowCounter() zapne ve spodním okraji počítadlo zobrazených řádků ve tvaru
+// "12–40 z 3214" (po filtrování), pokud se počet liší od počtu všech řádků
+// obsahu, je celkový počet v závorce "12–40 z 3214 (10000)"
+// Když se nevejde, vynechají se procenta a pak se počítadlo zkrátí
+func WithRowCounter(show bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.rowCounter = show
+	}
+}
 
-				m.filterInput, cmd = m.filterInput.Update(msg)
-				m = m.SetFilter(m.filterPrev)
+// WithRowCounterFormat() nastaví funkci pro formátování čísel v počítadle řádků,
+// např. s oddělovači tisíců, viz WithRowCounter()
+// Pokud není použito, čísla se formátují bez oddělovačů
+func WithRowCounterFormat(f func(n int) string) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.rowCounterFormat = f
+	}
+}
 
-				return m, cmd, nil
+// WithRowStyleFunc() nastaví funkci pro styl řádků podle jejich obsahu
+// Vrácený styl se použije nad styly řádků (WithLinesColors(), WithAlternateRowColors()),
+// vybraný a označený řádek mají přednost
+func WithRowStyleFunc(f RowStyleFunc) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.rowStyleFunc = f
+	}
+}
 
-			case tea.KeyEnter:
-				m.filterInputDisplayed = false
+// WithFooterColors() nastaví barvy patičky
+func WithFooterColors(fg, bg lipgloss.Color) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.footerStyle = lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Bold(true)
+	}
+}
 
-				m.filterInput, cmd = m.filterInput.Update(msg)
-				m = m.SetFilter(m.filterInput.Value())
-				m.filterPrev = m.filterInput.Value()
+// WithMarkedRowColors() nastaví barvy označených řádků
+// Vybraný řádek má vždy barvy podle WithSelectedLineColors(), i když je označený
+func WithMarkedRowColors(fg, bg lipgloss.Color) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.markedLineStyle = lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Bold(true)
+	}
+}
 
-				return m, cmd, nil
+// WithCheckableRows() zobrazí před prvním sloupečkem sloupeček s checkboxy
+// Zatržené řádky jsou označené řádky (GetMarkedRows()), klávesa ToggleMark
+// přepíná zatržení vybraného řádku a SelectAll zatržení všech řádků, checkbox
+// v headeru je zatržený, pokud jsou zatržené všechny zobrazené řádky
+func WithCheckableRows(checkable bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.checkableRows = checkable
+	}
+}
 
-			case tea.KeyRunes:
-				m.filterInput, cmd = m.filterInput.Update(msg)
-				m = m.SetFilter(m.filterInput.Value())
+// WithSortIndicators() nastaví symboly, které se při řazení připojí za header
+// řazeného sloupečku (asc pro vzestupné, desc pro sestupné řazení)
+// Pokud není použito, jsou symboly "▲" a "▼"
+func WithSortIndicators(asc, desc string) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.sortAscSymbol = asc
+		tm.sortDescSymbol = desc
+	}
+}
 
-				return m, cmd, nil
+// WithCheckboxSymbols() nastaví symboly checkboxů pro WithCheckableRows()
+// Pokud není použito, použijí se checkbox.DefaultSymbols
+func WithCheckboxSymbols(s checkbox.Symbols) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.checkboxSymbols = s
+	}
+}
 
-			default:
-				m.filterInput, cmd = m.filterInput.Update(msg)
-				m = m.SetFilter(m.filterInput.Value())
+// WithFilterColors() nastaví barvy pro filtr
+func WithFilterColors(fg, bg lipgloss.Color) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.filterStyle = lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Italic(true).Bold(true)
+	}
+}
 
-				return m, cmd, nil
-			}
-		}
+// WithEmptyText() nastaví text, který se zobrazí uprostřed tabulky, pokud
+// nejsou žádné řádky k zobrazení (prázdný obsah nebo nic neodpovídá filtru)
+// Prázdný text zobrazí prázdné řádky
+// Pokud není použito, zobrazují se prázdné řádky
+func WithEmptyText(text string) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.emptyText = text
+	}
+}
+
+// WithLoadingText() nastaví text, který se zobrazí při načítání, viz SetLoading()
+// Pokud není použito, zobrazuje se "Načítání…"
+func WithLoadingText(text string) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.loadingText = text
+	}
+}
+
+// WithLoading() zapne stav načítání hned po vytvoření tabulky, viz SetLoading()
+// Animaci spustí Init()
+func WithLoading(loading bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.loading = loading
+	}
+}
+
+// WithEmptyColors() nastaví barvy textu prázdné tabulky, viz WithEmptyText()
+func WithEmptyColors(fg, bg lipgloss.Color) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.emptyStyle = tm.emptyStyle.
+			Foreground(fg).
+			Background(bg)
+	}
+}
+
+// WithMatchColors() nastaví barvy zvýraznění části buňky, která odpovídá filtru
+// Ve vybraném řádku se shoda jen podtrhne, aby zůstaly barvy vybraného řádku
+func WithMatchColors(fg, bg lipgloss.Color) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.matchStyle = lipgloss.NewStyle().
+			Foreground(fg).
+			Background(bg)
+	}
+}
+
+// WithFilterColums() nastaví, podle kterých sloupečků se má filtrovat obsah pomocí SetFilter()
+// Pokud není nastaveno, filtruje podle všech sloupečků
+func WithFilterColums(cols ...int) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.filterColums = cols
+	}
+}
+
+// WithFuzzyFilter() zapne fuzzy filtrování - znaky filtru se hledají jako
+// podposloupnost (např. "ksys" najde "kube-system") a řádky jsou seřazené podle
+// skóre shody, nejlepší shody první
+// Pokud je nastavené řazení pomocí Sort(), má řazení přednost a skóre rozhoduje
+// jen o pořadí stejných hodnot
+// Pokud není použito, filtruje se podle obsahu podřetězce
+func WithFuzzyFilter(fuzzy bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.fuzzyFilter = fuzzy
+	}
+}
+
+// WithFilterScorer() nastaví vlastní FilterScorer pro fuzzy filtrování
+// Pokud není použito, použije se FuzzyScorer
+func WithFilterScorer(scorer FilterScorer) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.filterScorer = scorer
+	}
+}
+
+// WithEmitOnSet() nastaví, že i SetSelectedLine() a SelectLastLine() způsobí
+// poslání SelectionChangedMsg
+// Zpráva se vrací jako tea.Cmd z nejbližšího volání Update()
+// Pokud není použito, posílá se SelectionChangedMsg jen při ovládání klávesami
+func WithEmitOnSet(emit bool) func(*TableModel) {
+	return func(tm *TableModel) {
+		tm.emitOnSet = emit
+	}
+}
+
+// Init() standardní definice Init() pro bubbletea
+// Pokud je tabulka ve stavu načítání (WithLoading()), spustí animaci
+// S WithRowLoader() spustí načítání první části řádků
+func (m TableModel) Init() tea.Cmd {
+	var cmds []tea.Cmd
+
+	if m.loading {
+		cmds = append(cmds, m.loadingTick())
+	}
+	if m.loaderPending && len(m.content) == 0 {
+		cmds = append(cmds, m.loadRowsCmd())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Update() je standardní definice pro bubbletea
+// Návratové proměné jsou rozšířené o bubbletea.Msg
+//
+// Použití v hlavním modelu - na začátku funkce Update() zavolat:
+//
+//	m.table, cmd, msg = m.table.Update(msg)
+//
+// Pokud je předána klávesová zkratka, která je v modelu zaregistrovaná pro ovládání,
+// model si ji přebere a nepošle ji dál. Ostatní tea.KeyMsg i tea.Msg posílá zpět
+//
+// Po stisku klávesy Choose vrací tea.Cmd s RowChosenMsg pro vybraný řádek, pokud
+// je tabulka prázdná, klávesu posílá zpět
+// Pokud se zpracováním klávesy změní vybraný řádek, vrací tea.Cmd s SelectionChangedMsg
+//
+// Pokud je v bubbletea zapnutá myš, posouvá kolečko myši nad tabulkou pohled,
+// kliknutí vybere řádek, dvojklik pošle RowChosenMsg a kliknutí na header
+// přepíná řazení podle sloupečku
+// Pro správné rozpoznání plochy tabulky je potřeba nastavit WithPosition()
+//
+// Klávesy Yank a YankMarked kopírují vybraný/označené řádky do schránky, viz Yank()
+// S WithColumnCursor(true) posouvají klávesy ColumnLeft a ColumnRight kurzor sloupečku
+//
+// S WithEditable() otevře klávesa Edit editor vybrané buňky, dokud je otevřený,
+// dostává všechny klávesy, Enter úpravu potvrdí a vrací tea.Cmd s CellEditedMsg
+//
+// Klávesa GoTo otevře řádek pro zadání čísla řádku, Enter na řádek skočí
+// Klávesa Delete nic nemaže, vrací tea.Cmd s RowDeleteRequestedMsg, viz ConfirmDelete()
+//
+// Klávesa Detail otevře okno s detailem vybraného řádku (viz OpenDetail()), dokud
+// je otevřené, klávesy pro pohyb posouvají jeho obsah a Esc ho zavře
+//
+// S WithAutoSize(true) přebírá velikost z tea.WindowSizeMsg, zprávu posílá dál
+// S WithRowLoader() zpracovává RowsLoadedMsg a při posunu ke konci načtených
+// řádků vrací tea.Cmd pro načtení další části
+func (m TableModel) Update(msg tea.Msg) (TableModel, tea.Cmd, tea.Msg) {
+	var cmds []tea.Cmd
+
+	if m.pendingSelection != nil {
+		cmds = append(cmds, selectionChanged(*m.pendingSelection))
+		m.pendingSelection = nil
+	}
+
+	if m.pendingTick {
+		cmds = append(cmds, m.loadingTick())
+		m.pendingTick = false
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		if m.autoSize {
+			m = m.SetSize(max(msg.Width-m.offsetW, 0), max(msg.Height-m.offsetH, 0))
+
+			var cmd tea.Cmd
+			m, cmd = m.maybeLoadRows()
+			cmds = append(cmds, cmd)
+		}
+
+	case rowsLoadedMsg:
+		if msg.id != m.id {
+			break
+		}
+		if !m.loaderPending || msg.tag != m.loaderTag {
+			return m, tea.Batch(cmds...), nil
+		}
+
+		var ok bool
+		m, ok = m.rowsLoaded(msg.msg)
+		if ok {
+			var cmd tea.Cmd
+			m, cmd = m.maybeLoadRows()
+			cmds = append(cmds, cmd)
+		}
+
+		return m, tea.Batch(cmds...), nil
+
+	case loadingTickMsg:
+		if msg.id != m.id {
+			break
+		}
+		if !m.loading || msg.tag != m.loadingTag {
+			return m, tea.Batch(cmds...), nil
+		}
+
+		m.loadingFrame = (m.loadingFrame + 1) % len(loadingFrames)
+		cmds = append(cmds, m.loadingTick())
+
+		return m, tea.Batch(cmds...), nil
+
+	case cursor.BlinkMsg:
+		if m.editActive {
+			var cmd tea.Cmd
+			m.editInput, cmd = m.editInput.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+
+	case tea.KeyMsg, tea.MouseMsg:
+		if m.loading {
+			break
+		}
+
+		oldLine, oldIndex := m.selectedLine, m.selectedContentIndex()
+
+		var (
+			cmd tea.Cmd
+			ret tea.Msg
+		)
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			m, cmd, ret = m.handleKey(msg)
+		case tea.MouseMsg:
+			m, cmd, ret = m.handleMouse(msg)
+		}
+		cmds = append(cmds, cmd)
+
+		m, cmd = m.maybeLoadRows()
+		cmds = append(cmds, cmd)
+
+		if m.selectedLine != oldLine || m.selectedContentIndex() != oldIndex {
+			cmds = append(cmds, selectionChanged(m.selectionChangedMsg(oldLine)))
+		}
+
+		return m, tea.Batch(cmds...), ret
+	}
+
+	return m, tea.Batch(cmds...), msg
+}
+
+// loadingTick() vrátí tea.Cmd, který po loadingInterval pošle další loadingTickMsg
+func (m TableModel) loadingTick() tea.Cmd {
+	id, tag := m.id, m.loadingTag
+
+	return tea.Tick(loadingInterval, func(time.Time) tea.Msg {
+		return loadingTickMsg{id: id, tag: tag}
+	})
+}
+
+// handleKey() zpracuje klávesové zkratky pro Update()
+func (m TableModel) handleKey(msg tea.KeyMsg) (TableModel, tea.Cmd, tea.Msg) {
+	if m.editActive {
+		return m.handleEditKey(msg)
+	}
+
+	if m.detailOpen {
+		return m.handleDetailKey(msg)
+	}
+
+	if m.gotoActive {
+		return m.handleGoToKey(msg)
+	}
+
+	if m.filterInputDisplayed {
+		var cmd tea.Cmd
+
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.filterInputDisplayed = false
+
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m = m.SetFilter(m.filterPrev)
+
+			return m, cmd, nil
+
+		case tea.KeyEnter:
+			m.filterInputDisplayed = false
+
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m = m.SetFilter(m.filterInput.Value())
+			m.filterPrev = m.filterInput.Value()
+
+			return m, cmd, nil
+
+		case tea.KeyRunes:
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m = m.SetFilter(m.filterInput.Value())
+
+			return m, cmd, nil
+
+		default:
+			m.filterInput, cmd = m.filterInput.Update(msg)
+			m = m.SetFilter(m.filterInput.Value())
+
+			return m, cmd, nil
+		}
+	}
+
+	switch msg.String() {
+
+	case m.keys.SelectLineDown1, m.keys.SelectLineDown2, m.keys.SelectLineDown3:
+		m = m.selectLine(m.selectedLine + 1)
+
+	case m.keys.SelectLineUp1, m.keys.SelectLineUp2, m.keys.SelectLineUp3:
+		m = m.selectLine(m.selectedLine - 1)
+
+	case m.keys.MoveViewDown1, m.keys.MoveViewDown2, m.keys.MoveViewDown3:
+		m = m.ViewScroll(1)
+
+	case m.keys.MoveViewUp1, m.keys.MoveViewUp2, m.keys.MoveViewUp3:
+		m = m.ViewScroll(-1)
+
+	case m.keys.PageDown1, m.keys.PageDown2, m.keys.PageDown3:
+		m = m.PageScroll(1, true)
+
+	case m.keys.PageUp1, m.keys.PageUp2, m.keys.PageUp3:
+		m = m.PageScroll(-1, true)
+
+	case m.keys.Top1, m.keys.Top2, m.keys.Top3:
+		m = m.selectLine(0)
+
+	case m.keys.Bottom1, m.keys.Bottom2, m.keys.Bottom3:
+		m = m.selectLine(len(m.filteredContent) - 1)
+
+	case m.keys.Filter1, m.keys.Filter2, m.keys.Filter3:
+		m.filterInputDisplayed = true
+		m.filterInput.SetValue(m.filter)
+		m.filterInput.SetCursor(420)
+
+		return m, nil, nil
+
+	case m.keys.ClearFilter1, m.keys.ClearFilter2, m.keys.ClearFilter3:
+		m = m.SetFilter("")
+
+	case m.keys.Choose1, m.keys.Choose2, m.keys.Choose3:
+		if len(m.sortedContent) == 0 {
+			return m, nil, msg
+		}
+
+		return m, m.chooseRow(m.selectedLine), nil
+
+	case m.keys.ScrollLeft1, m.keys.ScrollLeft2, m.keys.ScrollLeft3:
+		if !m.horizontalScroll || len(m.headers) == 0 {
+			return m, nil, msg
+		}
+
+		m = m.scrollToPosition(m.colOffset - 1)
+
+		return m, nil, nil
+
+	case m.keys.ScrollRight1, m.keys.ScrollRight2, m.keys.ScrollRight3:
+		if !m.horizontalScroll || len(m.headers) == 0 {
+			return m, nil, msg
+		}
+
+		if cols, _ := m.layoutColumns(); m.columnPosition(cols[len(cols)-1]) < len(m.headers)-1 {
+			m.colOffset++
+		}
+
+		return m, nil, nil
+
+	case m.keys.ToggleMark1, m.keys.ToggleMark2, m.keys.ToggleMark3:
+		if len(m.sortedContent) == 0 {
+			return m, nil, msg
+		}
+
+		m = m.ToggleMark(m.selectedLine)
+
+		return m, nil, nil
+
+	case m.keys.SelectAll1, m.keys.SelectAll2, m.keys.SelectAll3:
+		if len(m.sortedContent) == 0 {
+			return m, nil, msg
+		}
+
+		m = m.ToggleMarkAll()
+
+		return m, nil, nil
+
+	case m.keys.CollapseGroup1, m.keys.CollapseGroup2, m.keys.CollapseGroup3:
+		i := m.selectedContentIndex()
+		if m.groupBy < 0 || i < 0 {
+			return m, nil, msg
+		}
+
+		m = m.SetGroupCollapsed(cellAt(m.content[i], m.groupBy), true)
+
+		return m, nil, nil
+
+	case m.keys.ColumnLeft1, m.keys.ColumnLeft2, m.keys.ColumnLeft3:
+		if !m.columnCursor || len(m.headers) == 0 {
+			return m, nil, msg
+		}
+
+		order := m.columnOrder()
+		m = m.SetSelectedColumn(order[max(m.columnPosition(m.selectedColumn())-1, 0)])
+
+		return m, nil, nil
+
+	case m.keys.ColumnRight1, m.keys.ColumnRight2, m.keys.ColumnRight3:
+		if !m.columnCursor || len(m.headers) == 0 {
+			return m, nil, msg
+		}
+
+		order := m.columnOrder()
+		m = m.SetSelectedColumn(order[min(m.columnPosition(m.selectedColumn())+1, len(order)-1)])
+
+		return m, nil, nil
+
+	case m.keys.GoTo1, m.keys.GoTo2, m.keys.GoTo3:
+		if len(m.sortedContent) == 0 {
+			return m, nil, msg
+		}
+
+		m = m.StartGoTo()
+
+		return m, nil, nil
+
+	case m.keys.Delete1, m.keys.Delete2, m.keys.Delete3:
+		cmd := m.requestDelete()
+		if cmd == nil {
+			return m, nil, msg
+		}
+
+		return m, cmd, nil
+
+	case m.keys.Edit1, m.keys.Edit2, m.keys.Edit3:
+		if m.selectedContentIndex() < 0 || m.editColumn() < 0 {
+			return m, nil, msg
+		}
+
+		m, cmd := m.StartEdit()
+
+		return m, cmd, nil
+
+	case m.keys.Yank1, m.keys.Yank2, m.keys.Yank3:
+		cmd := m.Yank()
+		if cmd == nil {
+			return m, nil, msg
+		}
+
+		return m, cmd, nil
+
+	case m.keys.YankMarked1, m.keys.YankMarked2, m.keys.YankMarked3:
+		cmd := m.YankMarked()
+		if cmd == nil {
+			return m, nil, msg
+		}
+
+		return m, cmd, nil
+
+	case m.keys.Detail1, m.keys.Detail2, m.keys.Detail3:
+		if m.selectedContentIndex() < 0 {
+			return m, nil, msg
+		}
+
+		m = m.OpenDetail()
+
+		return m, nil, nil
+
+	case m.keys.ExpandGroups1, m.keys.ExpandGroups2, m.keys.ExpandGroups3:
+		if m.groupBy < 0 {
+			return m, nil, msg
+		}
+
+		m = m.ExpandAllGroups()
+
+		return m, nil, nil
+
+	case m.keys.ToggleGroup1, m.keys.ToggleGroup2, m.keys.ToggleGroup3:
+		if _, ok := m.cursorGroup(); !ok {
+			return m, nil, msg
+		}
+
+		m = m.ToggleGroupAtCursor()
+
+		return m, nil, nil
+
+	default:
+		return m, nil, msg
+	}
+
+	return m, nil, msg
+}
+
+// handleMouse() zpracuje události myši pro Update()
+// Události mimo plochu tabulky posílá zpět
+func (m TableModel) handleMouse(msg tea.MouseMsg) (TableModel, tea.Cmd, tea.Msg) {
+	if m.editActive {
+		if !m.inArea(msg.X, msg.Y) {
+			return m, nil, msg
+		}
+
+		return m, nil, nil
+	}
+
+	if m.detailOpen {
+		return m.handleDetailMouse(msg)
+	}
+
+	if !m.inArea(msg.X, msg.Y) {
+		return m, nil, msg
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m = m.ViewScroll(-m.wheelDelta)
+
+	case tea.MouseButtonWheelDown:
+		m = m.ViewScroll(m.wheelDelta)
+
+	case tea.MouseButtonWheelLeft:
+		if !m.horizontalScroll || len(m.headers) == 0 {
+			return m, nil, msg
+		}
+		m = m.scrollToPosition(m.colOffset - 1)
+
+	case tea.MouseButtonWheelRight:
+		if !m.horizontalScroll || len(m.headers) == 0 {
+			return m, nil, msg
+		}
+		if cols, _ := m.layoutColumns(); m.columnPosition(cols[len(cols)-1]) < len(m.headers)-1 {
+			m.colOffset++
+		}
+
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil, msg
+		}
+
+		return m.handleClick(msg.X, msg.Y)
+
+	default:
+		return m, nil, msg
+	}
+
+	return m, nil, nil
+}
+
+// handleClick() zpracuje kliknutí levým tlačítkem myši na bod x, y
+// Kliknutí na header řadí podle sloupečku, kliknutí na řádek ho vybere,
+// dvojklik na řádek pošle RowChosenMsg
+func (m TableModel) handleClick(x, y int) (TableModel, tea.Cmd, tea.Msg) {
+	var (
+		cbWidth = m.checkboxWidth()
+		relX    = x - m.posX - m.frame() - m.numberColumnWidth()
+		onCb    = cbWidth > 0 && relX >= 0 && relX < cbWidth
+	)
+
+	if y >= m.headerY() && y < m.bodyY() {
+		if onCb {
+			return m.ToggleMarkAll(), nil, nil
+		}
+
+		col, ok := m.columnAt(x)
+		if !ok {
+			return m, nil, nil
+		}
+
+		switch {
+		case m.sortByCol != col || m.sortOrder == SortUnsorted:
+			m = m.Sort(col, SortAscendig)
+		case m.sortOrder == SortAscendig:
+			m = m.Sort(col, SortDescending)
+		default:
+			m = m.Sort(col, SortUnsorted)
+		}
+
+		return m, nil, nil
+	}
+
+	line := m.rowAt(y)
+	if line < 0 {
+		return m, nil, nil
+	}
+
+	if m.isGroupHeader(line) {
+		name := m.sortedContent[line][0]
+		return m.ToggleGroup(name), nil, nil
+	}
+
+	if onCb {
+		return m.ToggleMark(line), nil, nil
+	}
+
+	col, ok := m.columnAt(x)
+	if !ok {
+		return m, nil, nil
+	}
+	if m.columnCursor {
+		m.selectedCol = col
+	}
+
+	now := time.Now()
+	double := m.lastClickLine == line && now.Sub(m.lastClick) <= doubleClickInterval
+	m.lastClick, m.lastClickLine = now, line
+
+	m = m.selectLine(line)
+
+	if double {
+		m.lastClick = time.Time{}
+		return m, m.chooseRow(line), nil
+	}
+
+	return m, nil, nil
+}
+
+// headerY() vrátí řádek obrazovky, na kterém jsou headery
+func (m TableModel) headerY() int {
+	y := m.posY + m.frame()
+	if m.hasInputLine() {
+		y++
+	}
+
+	return y
+}
+
+// bodyY() vrátí řádek obrazovky, na kterém začínají řádky obsahu
+func (m TableModel) bodyY() int {
+	return m.headerY() + m.headerLines()
+}
+
+// rowAt() vrátí zobrazený řádek na řádku obrazovky y
+// Pokud na y není žádný řádek obsahu, vrátí -1
+func (m TableModel) rowAt(y int) int {
+	rel := y - m.bodyY()
+	if rel < 0 || m.loading {
+		return -1
+	}
+
+	// připnuté řádky mají vždy jeden řádek terminálu
+	if rel < m.pinnedTop {
+		return rel
+	}
+	rel -= m.pinnedTop
+	if rows := m.viewportRows(); rel >= rows {
+		if rel-rows < m.pinnedBottom {
+			return m.bodyEnd() + rel - rows
+		}
+
+		return -1
+	}
+
+	if !m.cellWrap {
+		line := m.scrolledTop + rel
+		if line >= m.bodyEnd() {
+			return -1
+		}
+
+		return line
+	}
+
+	cols, colSizes := m.layoutColumns()
+	for line := m.scrolledTop; line < m.bodyEnd(); line++ {
+		rel -= m.rowHeight(line, cols, colSizes)
+		if rel < 0 {
+			return line
+		}
+	}
+
+	return -1
+}
+
+// columnAt() vrátí index sloupečku ve sloupci obrazovky x
+// Pokud je na x okraj, oddělovač nebo checkbox, vrací ok == false
+func (m TableModel) columnAt(x int) (col int, ok bool) {
+	rel := x - m.posX - m.frame() - m.prefixWidth()
+
+	cols, colSizes := m.layoutColumns()
+	for _, i := range cols {
+		if rel < 0 {
+			return 0, false
+		}
+		if rel < colSizes[i] {
+			return i, true
+		}
+		rel -= colSizes[i] + 1
+	}
+
+	return 0, false
+}
+
+// inArea() vrátí true, pokud je bod x, y na obrazovce uvnitř tabulky
+func (m TableModel) inArea(x, y int) bool {
+	return x >= m.posX && x < m.posX+m.width &&
+		y >= m.posY && y < m.posY+m.height
+}
+
+// chooseRow() vrátí tea.Cmd, který pošle RowChosenMsg pro zobrazený řádek line
+func (m TableModel) chooseRow(line int) tea.Cmd {
+	if line < 0 || line >= len(m.sortedContent) || m.isGroupHeader(line) {
+		return nil
+	}
+
+	msg := RowChosenMsg{
+		Index:        line,
+		ContentIndex: m.sortedIndex[line],
+		Row:          m.sortedContent[line],
+	}
+
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// requestDelete() vrátí tea.Cmd s RowDeleteRequestedMsg pro označené řádky, nebo
+// pro vybraný řádek, pokud není nic označené
+// Pokud není co smazat, vrátí nil
+func (m TableModel) requestDelete() tea.Cmd {
+	var msg RowDeleteRequestedMsg
+
+	if marked := m.GetMarkedRows(); len(marked) > 0 {
+		msg = RowDeleteRequestedMsg{
+			Index:   -1,
+			Indexes: marked,
+			Rows:    m.GetMarkedRowContents(),
+		}
+	} else if i := m.selectedContentIndex(); i >= 0 {
+		msg = RowDeleteRequestedMsg{
+			Index:   i,
+			Row:     m.content[i],
+			Indexes: []int{i},
+			Rows:    [][]string{m.content[i]},
+		}
+	} else {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// selectedContentIndex() vrátí index vybraného řádku v content, pro prázdnou tabulku -1
+func (m TableModel) selectedContentIndex() int {
+	if m.selectedLine < 0 || m.selectedLine >= len(m.sortedIndex) {
+		return -1
+	}
+
+	return m.sortedIndex[m.selectedLine]
+}
+
+// selectionChangedMsg() sestaví SelectionChangedMsg pro aktuálně vybraný řádek
+func (m TableModel) selectionChangedMsg(oldLine int) SelectionChangedMsg {
+	msg := SelectionChangedMsg{
+		OldIndex:     oldLine,
+		NewIndex:     m.selectedLine,
+		ContentIndex: m.selectedContentIndex(),
+	}
+	if msg.ContentIndex >= 0 {
+		msg.Row = m.sortedContent[m.selectedLine]
+	}
+
+	return msg
+}
+
+// selectionChanged() vrátí tea.Cmd, který pošle SelectionChangedMsg
+func selectionChanged(msg SelectionChangedMsg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// lineStyle() vrátí styl pro zobrazený řádek line (index po filtrování a řazení)
+// Přednost má vybraný řádek, pak označený řádek, pak styl z rowStyleFunc a nakonec
+// střídání sudých a lichých řádků
+// Označený řádek dědí z ostatních stylů to, co sám nenastavuje (např. pozadí)
+func (m TableModel) lineStyle(line int) lipgloss.Style {
+	if line == m.selectedLine {
+		return m.selectedLineStyle
+	}
+
+	style := m.linesStyle
+	if m.alternateLines && line%2 == 1 {
+		style = m.alternateLinesStyle
+	}
+
+	if m.rowStyleFunc != nil {
+		style = m.rowStyleFunc(m.sortedIndex[line], m.sortedContent[line]).Inherit(style)
+	}
+
+	if m.marked[m.sortedIndex[line]] {
+		style = m.markedLineStyle.Inherit(style)
+	}
+
+	return style
+}
+
+// View() je standardní funkce pro bubbletea
+// Volat v hlavním modelu a výsledek spojit s ostatním výstupem
+func (m TableModel) View() string {
+	// stav může být nekonzistentní (např. po změně velikosti), vykresluje se
+	// vždy jen platný rozsah
+	m = m.clampPosition()
+
+	var (
+		s              string
+		rows           = m.viewportRows()
+		cols, colSizes = m.layoutColumns()
+	)
+
+	var (
+		headers string
+		table   string
+	)
+
+	cbWidth := m.checkboxWidth()
+
+	var top []string
+	if m.gotoActive {
+		top = append(top, m.gotoInput.View())
+	} else if m.filterInputDisplayed {
+		top = append(top, m.filterInput.View())
+	} else if m.filter != "" {
+		filter := truncate(m.filter, m.width-10)
+		top = append(top, m.filterStyle.Width(m.innerWidth()).Render(" Filtr: "+filter))
+	}
+	if m.showHeaders {
+		top = append(top, m.viewHeaders(cols, colSizes, cbWidth))
+	}
+	headers = strings.Join(top, "\n")
+
+	table = m.cachedBody(rows, cols, colSizes, cbWidth)
+
+	if m.hasFooter() {
+		footer := m.viewFooter(cols, colSizes, cbWidth)
+		if table == "" {
+			table = footer
+		} else {
+			table = lipgloss.JoinVertical(lipgloss.Top, table, footer)
+		}
+	}
+
+	if headers == "" {
+		s = table
+	} else {
+		s = lipgloss.JoinVertical(
+			lipgloss.Top, headers, table,
+		)
+	}
+
+	if m.border {
+		s = m.addBorders(s)
+	} else {
+		s = lipgloss.JoinHorizontal(lipgloss.Top, s, m.rightEdge(" ", m.height))
+	}
+
+	if m.detailOpen {
+		s = m.viewDetail(s)
+	}
+
+	return s
+
+}
+
+// viewHeaders() vykreslí řádek s headery (případně s checkboxem pro označení všech řádků)
+func (m TableModel) viewHeaders(cols, colSizes []int, cbWidth int) string {
+	height := m.headerLines()
+	headers := m.viewNumberCell("#", m.headerStyle, m.headerStyle, height)
+
+	if cbWidth > 0 {
+		headers = lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			headers,
+			m.headerStyle.Width(cbWidth).Height(height).Render(m.checkboxSymbols.Render(m.allChecked())),
+			m.separator(-1, m.headerStyle, height),
+		)
+	}
+
+	for n, i := range cols {
+		if n > 0 {
+			headers = lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				headers,
+				m.separator(cols[n-1], m.headerStyle, height),
+			)
+		}
+
+		var cell string
+		if m.headerTruncation {
+			h := m.sortedHeader(i, m.headers[i], colSizes[i])
+			cell = m.headerStyle.Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(h)
+		} else {
+			// druhý řádek headeru je zbytek zalomeného textu se symbolem řazení
+			first, rest, _ := strings.Cut(wrapCell(m.headers[i], colSizes[i]), "\n")
+			h := first + "\n" + m.sortedHeader(i, strings.ReplaceAll(rest, "\n", " "), colSizes[i])
+			cell = m.headerStyle.Width(colSizes[i]).Height(height).MaxWidth(colSizes[i]).Render(h)
+		}
+
+		headers = lipgloss.JoinHorizontal(lipgloss.Left, headers, cell)
+	}
+
+	return headers
+}
+
+// headerLines() vrátí počet řádků headerů (0 bez headerů, 2 bez zkracování headerů)
+func (m TableModel) headerLines() int {
+	switch {
+	case !m.showHeaders:
+		return 0
+	case !m.headerTruncation:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// sortedHeader() vrátí text h headeru sloupečku col zkrácený na šířku width
+// Pokud se podle sloupečku řadí, připojí symbol směru řazení a zkracuje se jen
+// text headeru
+func (m TableModel) sortedHeader(col int, h string, width int) string {
+	var indicator string
+	if m.sortByCol == col {
+		switch m.sortOrder {
+		case SortAscendig:
+			indicator = m.sortAscSymbol
+		case SortDescending:
+			indicator = m.sortDescSymbol
+		}
+	}
+	if indicator == "" {
+		return truncate(h, width)
+	}
+
+	if h == "" {
+		return truncate(indicator, width)
+	}
+
+	indicator = " " + indicator
+	iw := lipgloss.Width(indicator)
+	if width <= iw {
+		return truncate(strings.TrimLeft(indicator, " "), width)
+	}
+
+	return truncate(h, width-iw) + indicator
+}
+
+// cachedBody() vrátí vykreslené tělo tabulky (řádky obsahu bez headerů a patičky)
+// Pokud se od posledního vykreslení nezměnil stav (bodyKey), vrátí uložený výsledek
+func (m TableModel) cachedBody(rows int, cols, colSizes []int, cbWidth int) string {
+	// editor se mění s každou klávesou, při úpravě se neukládá
+	if m.cache == nil || m.editActive {
+		return m.viewBody(rows, cols, colSizes, cbWidth)
+	}
+
+	key := bodyKey{
+		rev:          m.rev,
+		width:        m.width,
+		rows:         rows,
+		scrolledTop:  m.scrolledTop,
+		selectedLine: m.selectedLine,
+		selectedCol:  m.selectedColumn(),
+		colOffset:    m.colOffset,
+		loading:      m.loading,
+		loadingFrame: m.loadingFrame,
+	}
+	if m.cache.valid && m.cache.key == key {
+		return m.cache.body
+	}
+
+	body := m.viewBody(rows, cols, colSizes, cbWidth)
+	*m.cache = renderCache{key: key, body: body, valid: true}
+
+	return body
+}
+
+// viewBody() vykreslí tělo tabulky, vykreslují se jen zobrazené řádky
+// Připnuté řádky jsou nad a pod řádky, které se posouvají
+func (m TableModel) viewBody(rows int, cols, colSizes []int, cbWidth int) string {
+	var (
+		body   []string
+		lines  int
+		pinned = m.pinnedTop + m.pinnedBottom
+	)
+
+	if m.loading {
+		if rows+pinned == 0 {
+			return ""
+		}
+
+		return m.viewPlaceholder(loadingFrames[m.loadingFrame]+" "+m.loadingText, rows+pinned)
+	}
+
+	for line := range m.pinnedTop {
+		body = append(body, m.viewPinnedRow(line, cols, colSizes, cbWidth))
+	}
+
+	for line := m.scrolledTop; line < m.bodyEnd() && lines < rows; line++ {
+		tl := m.viewRow(line, cols, colSizes, cbWidth)
+		if h := lipgloss.Height(tl); lines+h > rows {
+			tl = strings.Join(strings.Split(tl, "\n")[:rows-lines], "\n")
+		}
+		lines += lipgloss.Height(tl)
+
+		body = append(body, tl)
+	}
+
+	if m.loaderPending && len(m.sortedContent) > 0 && lines < rows && m.lastVisibleRow() >= m.bodyEnd()-1 {
+		body = append(body, m.viewPlaceholder(m.loadingText, 1))
+		lines++
+	}
+
+	if len(m.sortedContent) == 0 && rows > 0 {
+		if m.loaderPending {
+			body = []string{m.viewPlaceholder(m.loadingText, rows)}
+			lines = rows
+		} else if m.emptyText != "" {
+			body = []string{m.viewPlaceholder(m.emptyText, rows)}
+			lines = rows
+		}
+	}
+
+	if lines < rows {
+		fill := m.viewNumberCell("", m.linesStyle, m.linesStyle, 1)
+		if cbWidth > 0 {
+			fill += m.linesStyle.Width(cbWidth).Render(" ") +
+				m.separator(-1, m.linesStyle, 1)
+		}
+		for n, i := range cols {
+			if n > 0 {
+				fill = lipgloss.JoinHorizontal(
+					lipgloss.Left,
+					fill,
+					m.separator(cols[n-1], m.linesStyle, 1),
+				)
+			}
+			fill = lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				fill,
+				m.linesStyle.Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(" "),
+			)
+		}
+
+		for range rows - lines {
+			body = append(body, fill)
+		}
+	}
+
+	for line := m.bodyEnd(); line < len(m.sortedContent); line++ {
+		body = append(body, m.viewPinnedRow(line, cols, colSizes, cbWidth))
+	}
+
+	return strings.Join(body, "\n")
+}
+
+// viewPinnedRow() vykreslí připnutý řádek line, vždy na jeden řádek terminálu
+func (m TableModel) viewPinnedRow(line int, cols, colSizes []int, cbWidth int) string {
+	row, _, _ := strings.Cut(m.viewRow(line, cols, colSizes, cbWidth), "\n")
+
+	return row
+}
+
+// viewPlaceholder() vykreslí text uprostřed plochy pro řádky
+// Používá se pro prázdnou tabulku a načítání
+func (m TableModel) viewPlaceholder(text string, rows int) string {
+	width := max(m.innerWidth(), 0)
+
+	text = truncate(text, width)
+
+	return lipgloss.Place(
+		width, rows,
+		lipgloss.Center, lipgloss.Center,
+		m.emptyStyle.MaxWidth(width).Render(text),
+		lipgloss.WithWhitespaceBackground(m.linesStyle.GetBackground()),
+	)
+}
+
+// viewRow() vykreslí zobrazený řádek line pro zobrazené sloupečky cols
+// S WithCellWrap(true) může mít řádek více řádků terminálu
+func (m TableModel) viewRow(line int, cols, colSizes []int, cbWidth int) string {
+	if m.isGroupHeader(line) {
+		return m.viewGroupHeader(line)
+	}
+
+	var (
+		row    = m.sortedContent[line]
+		style  = m.lineStyle(line)
+		height = 1
+		cells  = make([]string, len(cols))
+	)
+
+	matchStyle := m.matchStyle.Inherit(style)
+	if line == m.selectedLine {
+		matchStyle = style.Underline(true)
+	}
+
+	styles := make([]lipgloss.Style, len(cols))
+	for n, i := range cols {
+		styles[n] = style
+		if line == m.selectedLine && i == m.selectedColumn() {
+			styles[n] = m.selectedCellStyle.Inherit(style)
+		}
+
+		if m.editActive && line == m.selectedLine && i == m.editCol && m.sortedIndex[line] == m.editRow {
+			cells[n] = m.editInput.View()
+			continue
+		}
+
+		col := m.formatCell(row, i)
+		if m.cellWrap {
+			col = wrapCell(col, colSizes[i])
+			height = max(height, lipgloss.Height(col))
+		} else {
+			col = truncate(col, colSizes[i])
+		}
+		if m.filter != "" && slices.Contains(m.filterColums, i) {
+			col = m.highlightMatches(col, styles[n], matchStyle.Inherit(styles[n]))
+		}
+		cells[n] = col
+	}
+
+	numStyle := style
+	if line != m.selectedLine {
+		numStyle = m.rowNumberStyle.Inherit(style)
+	}
+	tl := m.viewNumberCell(strconv.Itoa(m.rowNumber(line)), numStyle, style, height)
+
+	if cbWidth > 0 {
+		tl = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			tl,
+			style.Width(cbWidth).Height(height).Render(m.checkboxSymbols.Render(m.marked[m.sortedIndex[line]])),
+			m.separator(-1, style, height),
+		)
+	}
+
+	for n, i := range cols {
+		if n > 0 {
+			tl = lipgloss.JoinHorizontal(lipgloss.Left, tl, m.separator(cols[n-1], style, height))
+		}
+
+		var cell string
+		if m.cellWrap {
+			cell = styles[n].Width(colSizes[i]).Height(height).Render(cells[n])
+		} else {
+			cell = styles[n].Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(cells[n])
+		}
+		tl = lipgloss.JoinHorizontal(lipgloss.Left, tl, cell)
+	}
+
+	return tl
+}
+
+// highlightMatches() zvýrazní v textu buňky části odpovídající filtru stylem hl,
+// zbytek textu vykreslí stylem base
+// Zvýrazňuje se až zkrácený/zalomený text, každý řádek zvlášť
+// Text, který už obsahuje ANSI styly, vrátí beze změny
+func (m TableModel) highlightMatches(text string, base, hl lipgloss.Style) string {
+	if text != stripansi.Strip(text) {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for n, line := range lines {
+		mask := m.matchMask(line)
+		if mask == nil {
+			continue
+		}
+
+		var (
+			b     strings.Builder
+			runes = []rune(line)
+			start = 0
+		)
+		for i := 1; i <= len(runes); i++ {
+			if i < len(runes) && mask[i] == mask[start] {
+				continue
+			}
+			if mask[start] {
+				b.WriteString(hl.Render(string(runes[start:i])))
+			} else {
+				b.WriteString(base.Render(string(runes[start:i])))
+			}
+			start = i
+		}
+		lines[n] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// matchMask() vrátí pro každý znak textu, jestli je součástí shody s filtrem
+// Bez WithFuzzyFilter() se hledají všechny výskyty filtru, s fuzzy filtrem
+// první podposloupnost znaků filtru, obojí bez ohledu na velikost písmen
+// Pokud v textu žádná shoda není, vrátí nil
+func (m TableModel) matchMask(text string) []bool {
+	var (
+		runes = []rune(text)
+		lower = []rune(strings.ToLower(text))
+		f     = []rune(strings.ToLower(m.filter))
+	)
+
+	if len(f) == 0 || len(lower) != len(runes) {
+		return nil
+	}
+
+	mask := make([]bool, len(runes))
+
+	if m.fuzzyFilter {
+		fi := 0
+		for i, r := range lower {
+			if fi < len(f) && r == f[fi] {
+				mask[i] = true
+				fi++
+			}
+		}
+		if fi < len(f) {
+			return nil
+		}
+
+		return mask
+	}
+
+	found := false
+	for i := 0; i+len(f) <= len(lower); {
+		if !slices.Equal(lower[i:i+len(f)], f) {
+			i++
+			continue
+		}
+		for j := range f {
+			mask[i+j] = true
+		}
+		i += len(f)
+		found = true
+	}
+	if !found {
+		return nil
+	}
+
+	return mask
+}
+
+// truncate() zkrátí hodnotu na šířku width buněk terminálu a přidá "…"
+// Šířka se počítá bez ANSI sekvencí, sekvence zůstanou zachované a nerozdělené
+func truncate(value string, width int) string {
+	if lipgloss.Width(value) <= width {
+		return value
+	}
+
+	return ansi.Truncate(value, max(width, 0), "…")
+}
+
+// wrapCell() zalomí hodnotu buňky na šířku width, dlouhá slova rozdělí
+func wrapCell(value string, width int) string {
+	if width < 1 {
+		return value
+	}
+
+	return wrap.String(wordwrap.String(value, width), width)
+}
+
+// rowHeight() vrátí počet řádků terminálu, které zabere zobrazený řádek line
+func (m TableModel) rowHeight(line int, cols, colSizes []int) int {
+	if !m.cellWrap || m.isGroupHeader(line) || m.isPinnedLine(line) {
+		return 1
+	}
+
+	height := 1
+	for _, i := range cols {
+		height = max(height, lipgloss.Height(wrapCell(m.formatCell(m.sortedContent[line], i), colSizes[i])))
+	}
+
+	return height
+}
+
+// maxScrolledTop() vrátí největší možné scrolledTop, při kterém je ještě
+// zobrazený poslední řádek a okno je co nejvíce zaplněné
+func (m TableModel) maxScrolledTop() int {
+	rows := m.viewportRows()
+
+	if !m.cellWrap {
+		return max(m.bodyEnd()-rows, m.bodyStart())
+	}
+
+	cols, colSizes := m.layoutColumns()
+
+	top := m.bodyEnd()
+	used := 0
+	for top > m.bodyStart() {
+		h := m.rowHeight(top-1, cols, colSizes)
+		if used+h > rows {
+			break
+		}
+		used += h
+		top--
+	}
+
+	return min(top, max(m.bodyEnd()-1, m.bodyStart()))
+}
+
+// lastVisibleRow() vrátí index posledního celého zobrazeného řádku
+func (m TableModel) lastVisibleRow() int {
+	rows := m.viewportRows()
+
+	if !m.cellWrap {
+		return min(m.scrolledTop+rows, m.bodyEnd()) - 1
+	}
+
+	cols, colSizes := m.layoutColumns()
+
+	line := m.scrolledTop
+	used := 0
+	for line < m.bodyEnd() {
+		used += m.rowHeight(line, cols, colSizes)
+		if used > rows {
+			break
+		}
+		line++
+	}
+
+	return max(line-1, min(m.scrolledTop, m.bodyEnd()-1))
+}
+
+// scrollMetrics() vrátí celkový počet řádků obsahu a index prvního zobrazeného
+// S WithCellWrap(true) se počítají řádky terminálu, připnuté řádky se nepočítají
+func (m TableModel) scrollMetrics() (total, top int) {
+	if !m.cellWrap {
+		return m.bodyEnd() - m.bodyStart() + m.unloadedRows(), m.scrolledTop - m.bodyStart()
+	}
+
+	cols, colSizes := m.layoutColumns()
+
+	for line := m.bodyStart(); line < m.bodyEnd(); line++ {
+		h := m.rowHeight(line, cols, colSizes)
+		if line < m.scrolledTop {
+			top += h
+		}
+		total += h
+	}
+
+	return total + m.unloadedRows(), top
+}
+
+// separator() vykreslí stylem style oddělovač vysoký height řádků, který je za
+// sloupečkem col (-1 je checkbox)
+// Čára má barvu okraje (pokud je nastavená) a pozadí řádku, vypnutý oddělovač
+// je mezera
+func (m TableModel) separator(col int, style lipgloss.Style, height int) string {
+	sep := " "
+	if m.colSeparators && (col < 0 || len(m.separatorsAfter) == 0 || slices.Contains(m.separatorsAfter, col)) {
+		sep = m.borderType.Right
+		if fg := m.borderStyle.GetForeground(); fg != (lipgloss.NoColor{}) {
+			style = style.Foreground(fg)
+		}
+	}
+
+	return style.Render(strings.TrimSuffix(strings.Repeat(sep+"\n", height), "\n"))
+}
+
+// viewFooter() vykreslí patičku tabulky pro zobrazené sloupečky cols
+func (m TableModel) viewFooter(cols, colSizes []int, cbWidth int) string {
+	footer := m.viewNumberCell("", m.footerStyle, m.footerStyle, 1)
+	if cbWidth > 0 {
+		footer += m.footerStyle.Width(cbWidth).Render(" ") +
+			m.separator(-1, m.footerStyle, 1)
+	}
+
+	for n, i := range cols {
+		if n > 0 {
+			footer = lipgloss.JoinHorizontal(
+				lipgloss.Left,
+				footer,
+				m.separator(cols[n-1], m.footerStyle, 1),
+			)
+		}
+		col := cellAt(m.footer, i)
+		col = truncate(col, colSizes[i])
+		footer = lipgloss.JoinHorizontal(
+			lipgloss.Left,
+			footer,
+			m.footerStyle.Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(col),
+		)
+	}
+
+	return footer
+}
+
+// hasFooter() vrátí true, pokud se zobrazuje patička
+func (m TableModel) hasFooter() bool {
+	return len(m.footer) > 0
+}
+
+// viewportRows() vrátí počet řádků obsahu, které se vejdou do okna a posouvají se
+// Odečítá okraje, headery, řádek s filtrem, patičku a připnuté řádky
+func (m TableModel) viewportRows() int {
+	rows := m.height - m.headerLines() - 2*m.frame() - m.pinnedTop - m.pinnedBottom
+	if m.hasInputLine() {
+		rows--
+	}
+	if m.hasFooter() {
+		rows--
+	}
+
+	return max(rows, 0)
+}
+
+func (m TableModel) addBorders(table string) string {
+	contentLength, scrolledTop := m.scrollMetrics()
+
+	leftMore, rightMore := m.hiddenColumnIndicators()
+
+	borderTop := m.borderType.TopLeft + leftMore
+	if m.title == "" {
+		borderTop += strings.Repeat(
+			m.borderType.Top,
+			max(m.width-2-lipgloss.Width(leftMore)-lipgloss.Width(rightMore), 0),
+		)
+		borderTop += m.borderStyle.Render(rightMore + m.borderType.TopRight)
+	} else {
+		// místo pro titulek a čáry vedle něj bez rohů, závorek a indikátorů sloupečků
+		free := m.width - 4 - lipgloss.Width(leftMore) - lipgloss.Width(rightMore)
+		t := truncate(m.title, free)
+		tw := lipgloss.Width(t)
+
+		left := min(max(((m.width-1)/2)-(tw/2)-1-lipgloss.Width(leftMore), 0), max(free-tw, 0))
+		borderTop += strings.Repeat(m.borderType.Top, left)
+		borderTop += "[" + m.titleStyle.Render(t) + m.borderStyle.Render("]")
+		borderTop += m.borderStyle.Render(strings.Repeat(m.borderType.Top, max(free-tw-left, 0)))
+		borderTop += m.borderStyle.Render(rightMore + m.borderType.TopRight)
+	}
+	borderTop = m.borderStyle.Render(borderTop)
+
+	borderLeft := strings.Repeat(m.borderType.Left+"\n", m.height-3)
+	borderLeft += m.borderType.Left
+	borderLeft = m.borderStyle.Render(borderLeft)
+
+	rows := m.viewportRows()
+
+	borderRight := m.rightEdge(m.borderStyle.Render(m.borderType.Right), m.height-2)
+
+	borderBottom := m.viewBottomBorder(contentLength, scrolledTop, rows)
+
+	ret := lipgloss.JoinHorizontal(lipgloss.Left, borderLeft, table, borderRight)
+	ret = lipgloss.JoinVertical(lipgloss.Left, borderTop, ret)
+	ret = lipgloss.JoinVertical(lipgloss.Left, ret, borderBottom)
+
+	return ret
+}
+
+// viewBottomBorder() vykreslí spodní okraj s údaji o pozici (strana, procenta,
+// vybraný řádek) a textem z WithBottomTextFunc()
+// Pokud se text nevejde vedle procent, procenta se vynechají, pak se text zkrátí
+// Pokud se nevejdou ani samotné údaje o pozici, vynechají se postupně procenta,
+// strana a vybraný řádek
+func (m TableModel) viewBottomBorder(contentLength, scrolledTop, rows int) string {
+	position, total := m.dataPosition()
+	total += m.unloadedRows()
+
+	var pages, percent, pos string
+	if contentLength > 0 {
+		switch {
+		case m.pagination:
+			pages = fmt.Sprintf("[strana %d/%d]", m.GetPage()+1, m.GetPageCount())
+		case contentLength > rows && m.percentIndicator:
+			var p float64
+			if scrolledTop >= contentLength-rows {
+				p = 100
+			} else {
+				p = (float64(scrolledTop) / float64(contentLength-1)) * 100
+			}
+			percent = fmt.Sprintf("[%.0f%%]", p)
+		}
+		pos = fmt.Sprintf("[%d/%d]", position, total)
+	}
+
+	var text, counter string
+	if m.bottomTextFunc != nil {
+		text = m.bottomTextFunc(m)
+	}
+	if m.rowCounter && contentLength > 0 {
+		counter = m.rowCounterText()
+	}
+
+	// místo pro text bez rohů, závorek, čar vedle textu a ostatních údajů
+	free := func(segments ...string) int {
+		w := m.width - 6
+		for _, s := range segments {
+			if s != "" {
+				w -= lipgloss.Width(s) + 1
+			}
+		}
+		return w
+	}
+	// údaje o pozici bez textu potřebují o 4 znaky méně (rohy jsou započtené)
+	for _, drop := range []*string{&percent, &pages, &pos} {
+		if free(pages, percent, pos) >= -4 {
+			break
+		}
+		*drop = ""
+	}
+	if counter != "" {
+		if percent != "" && lipgloss.Width(counter) > free(pages, percent, pos) {
+			percent = ""
+		}
+		if counter = fitText(counter, free(pages, percent, pos)); counter != "" {
+			counter = "[" + counter + "]"
+		}
+	}
+	if text != "" && percent != "" && lipgloss.Width(text) > free(pages, counter, percent, pos) {
+		percent = ""
+	}
+
+	var right []string
+	for _, s := range []string{pages, counter, percent, pos} {
+		if s != "" {
+			right = append(right, s+m.borderType.Bottom)
+		}
+	}
+
+	var left string
+	if text = fitText(text, free(pages, counter, percent, pos)); text != "" {
+		text = "[" + m.titleStyle.Render(text) + m.borderStyle.Render("]")
+		if m.bottomTextLeft {
+			left = m.borderType.Bottom + text
+		} else {
+			right = slices.Insert(right, 0, text+m.borderType.Bottom)
+		}
+	}
+
+	rightText := strings.Join(right, "")
+	borderBottom := m.borderType.BottomLeft + left +
+		strings.Repeat(m.borderType.Bottom, max(m.width-2-lipgloss.Width(left)-lipgloss.Width(rightText), 0)) +
+		rightText +
+		m.borderType.BottomRight
+
+	return m.borderStyle.Render(borderBottom)
+}
+
+// fitText() zkrátí text na šířku width, pokud by ze zkráceného textu zbyla jen
+// výpustka, vrátí ""
+func fitText(text string, width int) string {
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	if width <= 1 {
+		return ""
+	}
+
+	return truncate(text, width)
+}
+
+// rowCounterText() vrátí text počítadla řádků, viz WithRowCounter()
+func (m TableModel) rowCounterText() string {
+	format := strconv.Itoa
+	if m.rowCounterFormat != nil {
+		format = m.rowCounterFormat
+	}
+
+	_, total := m.dataPosition()
+	total += m.unloadedRows()
+
+	var from, to int
+	if first, last := m.GetVisibleRange(); first >= 0 {
+		from, to = m.rowNumber(first), m.rowNumber(last)
+		if m.isGroupHeader(first) {
+			from++
+		}
+	}
+
+	text := format(from) + "–" + format(to) + " z " + format(total)
+	if all := len(m.content) + m.unloadedRows(); total != all {
+		text += " (" + format(all) + ")"
+	}
+
+	return text
+}
+
+// rightEdge() vykreslí pravý sloupec tabulky o height řádcích (pravý okraj, nebo
+// sloupec pro scrollbar bez okraje), řádky bez scrollbaru vyplní fill
+func (m TableModel) rightEdge(fill string, height int) string {
+	if !m.border && !m.reservesScrollBar() {
+		return ""
+	}
+
+	contentLength, scrolledTop := m.scrollMetrics()
+	rows := m.viewportRows()
+
+	showBar := !m.pagination && (m.scrollBar == ScrollBarAlways ||
+		(m.scrollBar == ScrollBarAuto && contentLength > rows))
+
+	right := make([]string, max(height, 1))
+	for i := range right {
+		right[i] = fill
+	}
+
+	if showBar && rows > 0 {
+		// scrollbar je jen vedle řádků obsahu, nad ním je header (případně filtr)
+		above := m.bodyY() - m.posY - m.frame() + m.pinnedTop
+		pos, size := m.scrollThumb(contentLength, scrolledTop, rows)
+
+		for l := range rows {
+			if above+l >= len(right) {
+				break
+			}
+			if l >= pos && l < pos+size {
+				right[above+l] = m.scrollBarStyleBar.Render("█")
+			} else {
+				right[above+l] = m.scrollBarStyleSpace.Render("░")
+			}
+		}
+	}
+
+	return strings.Join(right, "\n")
+}
+
+// frame() vrátí tloušťku okraje tabulky (1, nebo 0 bez okraje)
+func (m TableModel) frame() int {
+	if !m.border {
+		return 0
+	}
+
+	return 1
+}
+
+// reservesScrollBar() vrátí true, pokud má tabulka bez okraje vyhrazený sloupec
+// pro scrollbar
+func (m TableModel) reservesScrollBar() bool {
+	return !m.border && !m.pagination && m.scrollBar != ScrollBarNever
+}
+
+// innerWidth() vrátí šířku pro obsah tabulky (bez okraje, případně bez sloupce
+// pro scrollbar)
+func (m TableModel) innerWidth() int {
+	width := m.width - 2*m.frame()
+	if m.reservesScrollBar() {
+		width--
+	}
+
+	return width
+}
+
+// scrollThumb() vrátí pozici a velikost jezdce scrollbaru dlouhého track řádků
+// pro obsah o total řádcích posunutý o top řádků
+// Velikost odpovídá poměru zobrazené části obsahu (alespoň 1), jezdec je nahoře
+// právě při top == 0 a dole právě tehdy, když je zobrazený poslední řádek
+func (m TableModel) scrollThumb(total, top, track int) (pos, size int) {
+	if total <= track {
+		return 0, track
+	}
+
+	size = int(math.Round(float64(track) * float64(track) / float64(total)))
+	size = min(max(size, 1), track)
+	free := track - size
+
+	switch {
+	case top <= 0:
+		return 0, size
+	case m.lastVisibleRow() >= m.bodyEnd()-1:
+		return free, size
+	}
+
+	pos = int(math.Round(float64(top) / float64(total-track) * float64(free)))
+	pos = max(min(pos, free-1), 0)
+	if free >= 2 {
+		pos = max(pos, 1)
+	}
+
+	return pos, size
+}
+
+// Sort() seřadí tabulku podle sloupečku col a ve směru dir
+// Pro zrušení řazení předat do dir NoSort
+// Vybraný zůstává stejný řádek obsahu, pohled se posune tak, aby byl vidět
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) Sort(col int, dir SortOrder) TableModel {
+	if col > len(m.headers)-1 {
+		return m
+	}
+	m.sortByCol = col
+	m.sortOrder = dir
+
+	selected := m.selectedContentIndex()
+
+	m.sortedContent, m.sortedIndex, m.pinnedTop, m.pinnedBottom = m.pinRows(m.sortFilteredContent())
+	m.rev = lastRev.Add(1)
+
+	if len(m.sortedContent) == 0 {
+		return m
+	}
+
+	return m.reselect(selected, min(m.selectedLine, len(m.sortedContent)-1))
+}
+
+// GetSorting() vrátí sloupeček, podle kterého se řadí a směř řazení
+func (m TableModel) GetSorting() (sortCol int, sortOder SortOrder) {
+	return m.sortByCol, m.sortOrder
+}
+
+// SetFilter() nastaví filtr na tabulce
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetFilter(filter string) TableModel {
+	m.filter = filter
+	m = m.refreshContent()
+	m.scrolledTop = 0
+	m.selectedLine = 0
+
+	return m
+}
+
+// GetFilter() vrátí nastavený filtr na tabulce
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) GetFilter() string {
+	return m.filter
+}
+
+// SetHeaders() nastaví nové headery
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetHeaders(headers ...string) TableModel {
+	m.headers = headers
+	m.natural = m.measureColSizes()
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetContent() nastaví nové řádky, starý obsah zahodí
+// Vybraný řádek a posun pohledu se omezí na rozsah nového obsahu
+// Zruší i označení všech řádků, s WithKeyColumn() zachová vybraný a označené
+// řádky podle klíče
+// S WithMaxRows() zahodí nejstarší řádky nad nastavený počet
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetContent(rows ...[]string) TableModel {
+	var dropped int
+	rows, dropped = limitRows(rows, m.maxRows)
+	m.dropped += dropped
+
+	if m.keyColumn < 0 {
+		m.content = rows
+		m.marked = nil
+		m.pinned = nil
+		m = m.refreshContent()
+
+		return m.reselect(-1, m.selectedLine)
+	}
+
+	var (
+		selectedKey string
+		hasSelected bool
+		markedKeys  = make(map[string]bool)
+		pinnedKeys  = make(map[string]Edge)
+		offset      = m.selectedLine - m.scrolledTop
+	)
+
+	if i := m.selectedContentIndex(); i >= 0 {
+		selectedKey, hasSelected = cellAt(m.content[i], m.keyColumn), true
+	}
+	for i, v := range m.marked {
+		if v && i < len(m.content) {
+			markedKeys[cellAt(m.content[i], m.keyColumn)] = true
+		}
+	}
+
+	for i, edge := range m.pinned {
+		if i < len(m.content) {
+			pinnedKeys[cellAt(m.content[i], m.keyColumn)] = edge
+		}
+	}
+
+	m.content = rows
+	m.marked = nil
+	m.pinned = nil
+	for i, row := range rows {
+		key := cellAt(row, m.keyColumn)
+		if markedKeys[key] {
+			if m.marked == nil {
+				m.marked = make(map[int]bool)
+			}
+			m.marked[i] = true
+		}
+		if edge, ok := pinnedKeys[key]; ok {
+			if m.pinned == nil {
+				m.pinned = make(map[int]Edge)
+			}
+			m.pinned[i] = edge
+		}
+	}
+	m = m.refreshContent()
+
+	line := m.selectedLine
+	if hasSelected {
+		for l, row := range m.sortedContent {
+			if !m.isGroupHeader(l) && cellAt(row, m.keyColumn) == selectedKey {
+				line = l
+				break
+			}
+		}
+	}
+	m.scrolledTop = line - offset
+
+	return m.reselect(-1, line)
+}
+
+// SetColCompare() nastaví porovnání hodnot sloupečku col pro řazení, viz
+// WithColCompare()
+// Pro řazení jako text předat nil
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetColCompare(col int, cmp ColCompare) TableModel {
+	m.colCompares = maps.Clone(m.colCompares)
+	if m.colCompares == nil {
+		m.colCompares = make(map[int]ColCompare)
+	}
+	if cmp == nil {
+		delete(m.colCompares, col)
+	} else {
+		m.colCompares[col] = cmp
+	}
+
+	selected := m.selectedContentIndex()
+	m = m.refreshContent()
+
+	return m.reselect(selected, m.selectedLine)
+}
+
+// SetColFormatter() nastaví formátovač hodnot sloupečku col, viz WithColFormatter()
+// Pro zrušení formátovače předat nil
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetColFormatter(col int, f ColFormatter) TableModel {
+	m.colFormatters = maps.Clone(m.colFormatters)
+	if m.colFormatters == nil {
+		m.colFormatters = make(map[int]ColFormatter)
+	}
+	if f == nil {
+		delete(m.colFormatters, col)
+	} else {
+		m.colFormatters[col] = f
+	}
+
+	m.natural = m.measureColSizes()
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetColSizes() nastaví šířku sloupečků
+// Počet hodnot musí být stejný jako počet sloupečků
+// Pokud je velikost == 0, tak je použita automatická velikost
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetColSizes(s ...int) TableModel {
+	m.colSizes = s
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetColFlex() nastaví poměrné šířky sloupečků, viz WithColFlex()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetColFlex(weights ...float64) TableModel {
+	m.colFlex = weights
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetBorder() zapne nebo vypne okraj tabulky, viz WithBorder()
+// Posun pohledu a vybraný řádek se omezí podle nové výšky
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetBorder(border bool) TableModel {
+	m.border = border
+	m.filterInput.Width = m.innerWidth() - 9
+	m.rev = lastRev.Add(1)
+
+	if m.viewportRows() > 0 {
+		m = m.selectLine(m.selectedLine)
+	}
+
+	return m.clampPosition()
+}
+
+// SetShowHeaders() zobrazí nebo skryje řádek s headery, viz WithShowHeaders()
+// Posun pohledu zůstane stejný, jen se omezí tak, aby zůstal vidět vybraný řádek
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetShowHeaders(show bool) TableModel {
+	m.showHeaders = show
+
+	if m.viewportRows() > 0 {
+		m = m.selectLine(m.selectedLine)
+	}
+
+	return m.clampPosition()
+}
+
+// AppendContent() přidá další řádky
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+// S WithMaxRows() zahodí nejstarší řádky nad nastavený počet
+func (m TableModel) AppendContent(rows ...[]string) TableModel {
+	m.content = append(m.content, rows...)
+	if drop := len(m.content) - m.maxRows; m.maxRows > 0 && drop > 0 {
+		return m.dropOldest(drop)
+	}
+	m = m.refreshContent()
+
+	return m
+}
+
+// dropOldest() zahodí prvních n řádků obsahu
+// Označené a připnuté řádky i vybraný řádek se posunou, pohled zůstane na stejném
+// řádku obsahu (pokud nebyl zahozen)
+func (m TableModel) dropOldest(n int) TableModel {
+	selected := m.selectedContentIndex() - n
+	top := -1
+	if m.scrolledTop < len(m.sortedIndex) && m.sortedIndex[m.scrolledTop] >= n {
+		top = m.sortedIndex[m.scrolledTop] - n
+	}
+
+	m.content = slices.Clone(m.content[n:])
+	m.marked = maps.Clone(m.marked)
+	maps.DeleteFunc(m.marked, func(i int, _ bool) bool { return i < n })
+	m.marked = shiftMarks(m.marked, n, -n)
+	m.pinned = maps.Clone(m.pinned)
+	maps.DeleteFunc(m.pinned, func(i int, _ Edge) bool { return i < n })
+	m.pinned = shiftMarks(m.pinned, n, -n)
+	m.dropped += n
+
+	if m.editActive {
+		m.editRow -= n
+		if m.editRow < 0 {
+			m = m.CancelEdit()
+		}
+	}
+
+	m = m.refreshContent()
+	if line := slices.Index(m.sortedIndex, top); top >= 0 && line >= 0 {
+		m.scrolledTop = line
+	}
+
+	return m.reselect(selected, max(m.selectedLine-n, 0))
+}
+
+// limitRows() vrátí posledních limit řádků z rows a počet zahozených řádků,
+// limit <= 0 znamená bez omezení, viz WithMaxRows()
+func limitRows(rows [][]string, limit int) ([][]string, int) {
+	if drop := len(rows) - limit; limit > 0 && drop > 0 {
+		return rows[drop:], drop
+	}
+
+	return rows, 0
+}
+
+// GetDroppedCount() vrátí počet řádků, které zahodil WithMaxRows()
+func (m TableModel) GetDroppedCount() int {
+	return m.dropped
+}
+
+// InsertRow() vloží řádek row do obsahu na index index (index v GetContent())
+// Index mimo rozsah se omezí, index >= počet řádků přidá řádek na konec
+// Vybraný řádek i označené řádky zůstávají na stejných řádcích obsahu
+// S WithMaxRows() zahodí nejstarší řádky nad nastavený počet
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) InsertRow(index int, row []string) TableModel {
+	index = min(max(index, 0), len(m.content))
 
-		switch msg.String() {
+	selected := m.selectedContentIndex()
+	if selected >= index {
+		selected++
+	}
+
+	m.content = slices.Insert(slices.Clone(m.content), index, row)
+	m.marked = shiftMarks(m.marked, index, 1)
+	m.pinned = shiftMarks(m.pinned, index, 1)
+	m = m.refreshContent()
+	m = m.reselect(selected, m.selectedLine)
+
+	if drop := len(m.content) - m.maxRows; m.maxRows > 0 && drop > 0 {
+		return m.dropOldest(drop)
+	}
+
+	return m
+}
+
+// DeleteRow() smaže řádek obsahu s indexem index (index v GetContent())
+// Index mimo rozsah se omezí na první/poslední řádek
+// Pokud je smazaný řádek vybraný, vybere se následující zobrazený řádek
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) DeleteRow(index int) TableModel {
+	if len(m.content) == 0 {
+		return m
+	}
+	index = min(max(index, 0), len(m.content)-1)
+
+	selected := m.selectedContentIndex()
+	switch {
+	case selected == index:
+		selected = -1
+	case selected > index:
+		selected--
+	}
+
+	m.content = slices.Delete(slices.Clone(m.content), index, index+1)
+	if m.marked[index] {
+		m.marked = maps.Clone(m.marked)
+		delete(m.marked, index)
+	}
+	if _, ok := m.pinned[index]; ok {
+		m.pinned = maps.Clone(m.pinned)
+		delete(m.pinned, index)
+	}
+	m.marked = shiftMarks(m.marked, index+1, -1)
+	m.pinned = shiftMarks(m.pinned, index+1, -1)
+	m = m.refreshContent()
+
+	return m.reselect(selected, m.selectedLine)
+}
+
+// ConfirmDelete() smaže řádky obsahu s indexy indexes (indexy v GetContent()),
+// typicky Indexes z RowDeleteRequestedMsg
+// Indexy mimo rozsah se ignorují, výběr a posun pohledu se upraví jako v DeleteRow()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ConfirmDelete(indexes ...int) TableModel {
+	indexes = slices.Clone(indexes)
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+
+	for _, index := range slices.Backward(indexes) {
+		if index >= 0 && index < len(m.content) {
+			m = m.DeleteRow(index)
+		}
+	}
+
+	return m
+}
+
+// UpdateRow() nahradí řádek obsahu s indexem index (index v GetContent())
+// Index mimo rozsah se omezí na první/poslední řádek
+// Vybraný řádek zůstává vybraný, i když se kvůli řazení přesune
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) UpdateRow(index int, row []string) TableModel {
+	if len(m.content) == 0 {
+		return m
+	}
+	index = min(max(index, 0), len(m.content)-1)
+
+	selected := m.selectedContentIndex()
+
+	m.content = slices.Clone(m.content)
+	m.content[index] = row
+	m = m.refreshContent()
+
+	return m.reselect(selected, m.selectedLine)
+}
+
+// UpdateCell() nastaví hodnotu buňky ve sloupečku col řádku obsahu row (index v GetContent())
+// Indexy mimo rozsah se omezí na první/poslední řádek a sloupeček
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) UpdateCell(row, col int, value string) TableModel {
+	if len(m.content) == 0 {
+		return m
+	}
+	row = min(max(row, 0), len(m.content)-1)
+	col = min(max(col, 0), len(m.headers)-1)
+
+	cells := slices.Clone(m.content[row])
+	if len(cells) <= col {
+		cells = append(cells, make([]string, col-len(cells)+1)...)
+	}
+	cells[col] = value
+
+	return m.UpdateRow(row, cells)
+}
+
+// reselect() po změně obsahu vybere zobrazený řádek, který je v content na indexu index
+// Pokud takový řádek není zobrazený, vybere zobrazený řádek line omezený na rozsah tabulky
+// Zároveň omezí posun pohledu, aby nebyl za koncem obsahu
+func (m TableModel) reselect(index, line int) TableModel {
+	if i := slices.Index(m.sortedIndex, index); index >= 0 && i >= 0 {
+		line = i
+	}
+
+	m.selectedLine = line
+	m = m.clampPosition()
+
+	return m.selectLine(m.selectedLine)
+}
+
+// shiftMarks() vrátí označené (nebo připnuté) řádky, kde jsou indexy >= from
+// posunuté o delta
+func shiftMarks[V any](marked map[int]V, from, delta int) map[int]V {
+	if len(marked) == 0 {
+		return marked
+	}
+
+	shifted := make(map[int]V, len(marked))
+	for i, v := range marked {
+		if i >= from {
+			i += delta
+		}
+		shifted[i] = v
+	}
+
+	return shifted
+}
+
+// GetContent() vrátí celý obsah, i když je nastavený filter
+func (m TableModel) GetContent() [][]string {
+	return m.content
+}
+
+// GetRow() vrátí kopii řádku obsahu s indexem row (index v GetContent())
+// Pokud je index mimo rozsah, vrátí ok == false
+func (m TableModel) GetRow(row int) (cells []string, ok bool) {
+	if row < 0 || row >= len(m.content) {
+		return nil, false
+	}
+
+	return slices.Clone(m.content[row]), true
+}
+
+// GetCell() vrátí hodnotu buňky řádku row (index v GetContent()) a sloupečku col
+// (index v headerech), bez formátování
+// Pokud je index mimo rozsah, vrátí ok == false, chybějící buňka kratšího řádku
+// je ""
+func (m TableModel) GetCell(row, col int) (value string, ok bool) {
+	if row < 0 || row >= len(m.content) || col < 0 || col >= len(m.headers) {
+		return "", false
+	}
+
+	return cellAt(m.content[row], col), true
+}
+
+// GetFilteredContent() vrátí obsah, pokud je nastavený filtr, tak filtrovaný
+func (m TableModel) GetFilteredContent() [][]string {
+	return m.filteredContent
+}
+
+// GetHeaders() vrátí kopii headerů v pořadí, v jakém byly předané (bez ohledu
+// na pořadí zobrazení sloupečků)
+func (m TableModel) GetHeaders() []string {
+	return slices.Clone(m.headers)
+}
+
+// GetSize() vrátí šířku a výšku tabulky, jak byla nastavená přes SetSize()
+func (m TableModel) GetSize() (width, height int) {
+	return m.width, m.height
+}
+
+// GetViewportRows() vrátí počet řádků obsahu, které se vejdou do tabulky, bez
+// řádku filtru, patičky a připnutých řádků
+func (m TableModel) GetViewportRows() int {
+	return m.viewportRows()
+}
+
+// SetSize() nastaví velikost okna
+// Posun pohledu se omezí tak, aby zůstal vidět vybraný řádek
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetSize(width, height int) TableModel {
+	m.width, m.height = width, height
+	m.filterInput.Width = m.innerWidth() - 9
+
+	return m.fitViewport()
+}
+
+// fitViewport() po změně počtu řádků pro obsah (velikost, patička) posune pohled
+// tak, aby byl vybraný řádek vidět
+func (m TableModel) fitViewport() TableModel {
+	if m.viewportRows() > 0 {
+		m = m.selectLine(m.selectedLine)
+	}
+
+	return m.clampPosition()
+}
+
+// SetSelectedLine() nastaví vybraný řádek
+// Pokud je použito WithEmitOnSet(true) a vybraný řádek se změní, vrátí nejbližší
+// volání Update() tea.Cmd s SelectionChangedMsg
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetSelectedLine(line int) TableModel {
+	oldLine, oldIndex := m.selectedLine, m.selectedContentIndex()
+
+	m = m.selectLine(line)
+
+	if m.emitOnSet && (m.selectedLine != oldLine || m.selectedContentIndex() != oldIndex) {
+		if m.pendingSelection != nil {
+			oldLine = m.pendingSelection.OldIndex
+		}
+		msg := m.selectionChangedMsg(oldLine)
+		m.pendingSelection = &msg
+	}
+
+	return m
+}
+
+// selectLine() nastaví vybraný řádek a posune pohled tak, aby byl vidět
+func (m TableModel) selectLine(line int) TableModel {
+	if m.isGroupHeader(line) {
+		dir := 1
+		if line < m.selectedLine {
+			dir = -1
+		}
+		line = m.nearestDataRow(line, dir)
+	}
+
+	// připnuté řádky jsou vidět vždy, pohled se neposouvá
+	if m.isPinnedLine(line) {
+		m.selectedLine = line
+		return m
+	}
+
+	if line < len(m.sortedContent) && line >= 0 && m.pagination {
+		m.selectedLine = line
+		return m.clampPosition()
+	}
+
+	if line < len(m.sortedContent) && line >= 0 {
+		m.selectedLine = line
+
+		if m.selectedLine < m.scrolledTop {
+			m.scrolledTop = m.selectedLine
+			// řádek skupiny nad vybraným řádkem zůstane vidět
+			if m.isGroupHeader(m.selectedLine - 1) {
+				m.scrolledTop--
+			}
+		} else if m.cellWrap {
+			cols, colSizes := m.layoutColumns()
+			rows := m.viewportRows()
+
+			top := m.selectedLine
+			used := m.rowHeight(top, cols, colSizes)
+			for top > m.scrolledTop && used+m.rowHeight(top-1, cols, colSizes) <= rows {
+				top--
+				used += m.rowHeight(top, cols, colSizes)
+			}
+			m.scrolledTop = top
+		} else if rows := m.viewportRows(); m.selectedLine >= m.scrolledTop+rows {
+			m.scrolledTop = m.selectedLine - rows + 1
+		}
+	}
+
+	return m
+}
+
+// GetVisibleRange() vrátí první a poslední zobrazený řádek (index v pořadí zobrazení)
+// S WithCellWrap(true) je poslední i řádek, který je zobrazený jen zčásti
+// Připnuté řádky (PinRow()) se nepočítají, ty jsou zobrazené vždy
+// Pokud není zobrazený žádný řádek, vrátí -1, -1
+func (m TableModel) GetVisibleRange() (first, last int) {
+	m = m.clampPosition()
+
+	rows := m.viewportRows()
+	if m.bodyEnd() <= m.bodyStart() || rows == 0 || m.loading {
+		return -1, -1
+	}
+
+	if !m.cellWrap {
+		return m.scrolledTop, min(m.scrolledTop+rows, m.bodyEnd()) - 1
+	}
+
+	cols, colSizes := m.layoutColumns()
+
+	last = m.scrolledTop
+	for used := 0; last < m.bodyEnd(); last++ {
+		used += m.rowHeight(last, cols, colSizes)
+		if used >= rows {
+			break
+		}
+	}
+
+	return m.scrolledTop, min(last, m.bodyEnd()-1)
+}
+
+// GetRowCount() vrátí počet zobrazených řádků (po filtrování, bez řádků skupin
+// a řádků ve sbalených skupinách)
+func (m TableModel) GetRowCount() int {
+	_, total := m.dataPosition()
+
+	return total
+}
+
+// GetTotalRowCount() vrátí počet všech řádků obsahu (bez filtrování)
+func (m TableModel) GetTotalRowCount() int {
+	return len(m.content)
+}
+
+// GetScrolledTop() vrátí index prvního zobrazeného řádku (v pořadí zobrazení)
+func (m TableModel) GetScrolledTop() int {
+	return m.clampPosition().scrolledTop
+}
+
+// GetSelectedLine() vrátí index aktuálně vybraného řádku
+func (m TableModel) GetSelectedLine() int {
+	return m.selectedLine
+}
+
+// SelectLastLine() nastaví vybraný řádek na poslední
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SelectLastLine() TableModel {
+	m = m.SetSelectedLine(len(m.sortedContent) - 1)
+
+	return m
+}
+
+// ViewScroll() posune pohled o num řádků dolů/nahoru
+// Neposunuje aktuálně vybraný řádek
+// Pokud je num < 0, posouvá pohled nahoru o num řádků
+// Pokud je num > 0, posouvá pohled dolů o num řádků
+// Pohled se posune nejvýše na začátek/konec obsahu
+// Při stránkování (WithPagination()) přejde na další/předchozí stránku
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ViewScroll(num int) TableModel {
+	if m.pagination {
+		switch {
+		case num > 0:
+			return m.SetPage(m.GetPage() + 1)
+		case num < 0:
+			return m.SetPage(m.GetPage() - 1)
+		}
+
+		return m
+	}
+
+	if num > 0 {
+		m.scrolledTop = max(min(m.scrolledTop+num, m.maxScrolledTop()), m.scrolledTop)
+	} else if num < 0 {
+		m.scrolledTop = max(m.scrolledTop+num, m.bodyStart())
+	}
+
+	return m
+}
+
+// ScrollToRow() posune pohled tak, aby byl zobrazený řádek index (v pořadí zobrazení)
+// na pozici pos: lipgloss.Top nahoře, lipgloss.Center uprostřed, lipgloss.Bottom dole
+// Neposunuje aktuálně vybraný řádek (kromě stránkování, kde přejde na stránku
+// s řádkem index, viz SetPage()), index mimo rozsah se omezí
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ScrollToRow(index int, pos lipgloss.Position) TableModel {
+	if len(m.sortedContent) == 0 {
+		return m
+	}
+	index = min(max(index, 0), len(m.sortedContent)-1)
+	if m.isPinnedLine(index) {
+		return m
+	}
+	if m.pagination {
+		return m.SetPage(m.pageOf(index))
+	}
+
+	var (
+		cols, colSizes = m.layoutColumns()
+		rows           = m.viewportRows()
+		height         = m.rowHeight(index, cols, colSizes)
+		offset         = max(int(math.Round(float64(pos)*float64(rows-height))), 0)
+	)
+
+	top, used := index, 0
+	for top > m.bodyStart() && used+m.rowHeight(top-1, cols, colSizes) <= offset {
+		top--
+		used += m.rowHeight(top, cols, colSizes)
+	}
+	m.scrolledTop = top
+
+	return m.clampPosition()
+}
+
+// EnsureVisible() posune pohled co nejméně tak, aby byl zobrazený řádek index
+// (v pořadí zobrazení), pokud už zobrazený je, nic nedělá
+// Neposunuje aktuálně vybraný řádek, index mimo rozsah se omezí
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) EnsureVisible(index int) TableModel {
+	if len(m.sortedContent) == 0 {
+		return m
+	}
+	index = min(max(index, 0), len(m.sortedContent)-1)
+
+	switch {
+	case m.isPinnedLine(index):
+		return m
+	case index < m.scrolledTop:
+		return m.ScrollToRow(index, lipgloss.Top)
+	case index > m.lastVisibleRow():
+		return m.ScrollToRow(index, lipgloss.Bottom)
+	}
+
+	return m
+}
+
+// PageScroll() posune pohled o stránku dolů/nahoru
+// Pokud je moveSelected == true, posune i aktuálně vybraný řádek
+// Pokud je num < 0, posune pohled o num stránek nahoru
+// Pokud je num > 0, posune pohled o num stránek dolů
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) PageScroll(num int, moveSelected bool) TableModel {
+	if m.pagination {
+		return m.SetPage(m.GetPage() + num)
+	}
+
+	if m.cellWrap {
+		return m.pageScrollWrapped(num, moveSelected)
+	}
+
+	m.scrolledTop += m.viewportRows() * num
+	m = m.clampPosition()
+
+	if moveSelected && len(m.sortedContent) > 0 {
+		if num > 0 {
+			m.selectedLine = m.lastVisibleRow()
+		} else if num < 0 {
+			m.selectedLine = m.scrolledTop
+		}
+	}
+
+	return m.clampPosition()
+}
+
+// clampPosition() omezí posun pohledu na bodyStart()..maxScrolledTop() a vybraný řádek
+// na rozsah zobrazených řádků, vybraný řádek nikdy není řádek skupiny
+func (m TableModel) clampPosition() TableModel {
+	m.scrolledTop = max(min(m.scrolledTop, m.maxScrolledTop()), m.bodyStart())
+	m.selectedLine = max(min(m.selectedLine, len(m.sortedContent)-1), 0)
+
+	if m.isGroupHeader(m.selectedLine) {
+		if line := m.nearestDataRow(m.selectedLine, 1); line >= 0 {
+			m.selectedLine = line
+		}
+	}
+
+	// při stránkování je pohled na stránce s vybraným řádkem
+	if m.pagination {
+		line := m.selectedLine
+		if m.isPinnedLine(line) {
+			line = m.scrolledTop
+		}
+		m.scrolledTop = m.pageStart(m.pageOf(line))
+	}
+
+	return m
+}
+
+// pageScrollWrapped() je PageScroll() pro WithCellWrap(true), stránka se počítá
+// v řádcích terminálu
+func (m TableModel) pageScrollWrapped(num int, moveSelected bool) TableModel {
+	var (
+		cols, colSizes = m.layoutColumns()
+		rows           = m.viewportRows()
+		maxTop         = m.maxScrolledTop()
+	)
+
+	for range max(num, -num) {
+		if num > 0 {
+			next := m.lastVisibleRow() + 1
+			m.scrolledTop = min(max(next, m.scrolledTop+1), maxTop)
+		} else {
+			top := m.scrolledTop
+			used := 0
+			for top > m.bodyStart() && used+m.rowHeight(top-1, cols, colSizes) <= rows {
+				top--
+				used += m.rowHeight(top, cols, colSizes)
+			}
+			if top == m.scrolledTop {
+				top = max(top-1, m.bodyStart())
+			}
+			m.scrolledTop = top
+		}
+	}
+
+	if moveSelected && len(m.sortedContent) > 0 {
+		if num > 0 {
+			m.selectedLine = m.lastVisibleRow()
+		} else if num < 0 {
+			m.selectedLine = m.scrolledTop
+		}
+	}
+
+	return m.clampPosition()
+}
+
+// ToggleMark() přepne označení zobrazeného řádku line (index po filtrování a řazení)
+// Označení patří k řádku obsahu, takže vydrží posouvání, řazení i filtrování
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ToggleMark(line int) TableModel {
+	if line < 0 || line >= len(m.sortedIndex) {
+		return m
+	}
+
+	m.marked = maps.Clone(m.marked)
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	m.rev = lastRev.Add(1)
+
+	i := m.sortedIndex[line]
+	if i < 0 {
+		return m
+	}
+	if m.marked[i] {
+		delete(m.marked, i)
+	} else {
+		m.marked[i] = true
+	}
+
+	return m
+}
+
+// ToggleMarkAll() označí všechny zobrazené řádky (při aktivním filtru jen
+// vyfiltrované), pokud už jsou všechny označené, tak jejich označení zruší
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ToggleMarkAll() TableModel {
+	all := m.allChecked()
+
+	m.marked = maps.Clone(m.marked)
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	m.rev = lastRev.Add(1)
+
+	for _, i := range m.sortedIndex {
+		if i < 0 {
+			continue
+		}
+		if all {
+			delete(m.marked, i)
+		} else {
+			m.marked[i] = true
+		}
+	}
 
-		case m.keys.SelectLineDown1, m.keys.SelectLineDown2, m.keys.SelectLineDown3:
-			m = m.SetSelectedLine(m.selectedLine + 1)
+	return m
+}
 
-		case m.keys.SelectLineUp1, m.keys.SelectLineUp2, m.keys.SelectLineUp3:
-			m = m.SetSelectedLine(m.selectedLine - 1)
+// ClearMarks() zruší označení všech řádků
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ClearMarks() TableModel {
+	m.marked = nil
+	m.rev = lastRev.Add(1)
 
-		case m.keys.MoveViewDown1, m.keys.MoveViewDown2, m.keys.MoveViewDown3:
-			m = m.ViewScroll(1)
+	return m
+}
 
-		case m.keys.MoveViewUp1, m.keys.MoveViewUp2, m.keys.MoveViewUp3:
-			m = m.ViewScroll(-1)
+// GetMarkedRows() vrátí seřazené indexy označených řádků v celém obsahu (GetContent())
+func (m TableModel) GetMarkedRows() []int {
+	rows := slices.Collect(maps.Keys(m.marked))
+	slices.Sort(rows)
 
-		case m.keys.PageDown1, m.keys.PageDown2, m.keys.PageDown3:
-			m = m.PageScroll(1, true)
+	return rows
+}
 
-		case m.keys.PageUp1, m.keys.PageUp2, m.keys.PageUp3:
-			m = m.PageScroll(-1, true)
+// GetMarkedRowContents() vrátí označené řádky v pořadí podle celého obsahu
+func (m TableModel) GetMarkedRowContents() [][]string {
+	var rows [][]string
+	for _, i := range m.GetMarkedRows() {
+		rows = append(rows, m.content[i])
+	}
 
-		case m.keys.Top1, m.keys.Top2, m.keys.Top3:
-			m = m.SetSelectedLine(0)
+	return rows
+}
 
-		case m.keys.Bottom1, m.keys.Bottom2, m.keys.Bottom3:
-			m = m.SelectLastLine()
+// GetCheckedRows() vrátí seřazené indexy zatržených řádků v celém obsahu (GetContent())
+// Zatržené řádky jsou totéž co označené řádky, viz GetMarkedRows()
+func (m TableModel) GetCheckedRows() []int {
+	return m.GetMarkedRows()
+}
 
-		case m.keys.Filter1, m.keys.Filter2, m.keys.Filter3:
-			m.filterInputDisplayed = true
-			m.filterInput.SetValue(m.filter)
-			m.filterInput.SetCursor(420)
+// SetChecked() nastaví zatržení řádku index v celém obsahu (GetContent())
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetChecked(index int, v bool) TableModel {
+	if index < 0 || index >= len(m.content) || m.marked[index] == v {
+		return m
+	}
 
-			return m, nil, nil
+	m.marked = maps.Clone(m.marked)
+	if m.marked == nil {
+		m.marked = make(map[int]bool)
+	}
+	m.rev = lastRev.Add(1)
 
-		case m.keys.ClearFilter1, m.keys.ClearFilter2, m.keys.ClearFilter3:
-			m = m.SetFilter("")
+	if v {
+		m.marked[index] = true
+	} else {
+		delete(m.marked, index)
+	}
 
-		default:
-			return m, nil, msg
-		}
+	return m
+}
 
+// checkboxWidth() vrátí šířku sloupečku s checkboxy, 0 pokud se nezobrazuje
+func (m TableModel) checkboxWidth() int {
+	if !m.checkableRows {
+		return 0
 	}
 
-	return m, nil, msg
+	return max(
+		lipgloss.Width(m.checkboxSymbols.Render(true)),
+		lipgloss.Width(m.checkboxSymbols.Render(false)),
+	)
 }
 
-// View() je standardní funkce pro bubbletea
-// Volat v hlavním modelu a výsledek spojit s ostatním výstupem
-func (m TableModel) View() string {
-	height := m.height
-	if m.filter != "" || m.filterInputDisplayed {
-		height--
+// allChecked() vrátí true, pokud jsou zatržené všechny zobrazené řádky
+func (m TableModel) allChecked() bool {
+	all := false
+	for _, i := range m.sortedIndex {
+		if i < 0 {
+			continue
+		}
+		if !m.marked[i] {
+			return false
+		}
+		all = true
 	}
 
-	var (
-		s           string
-		linesHeight = min(height-3+m.scrolledTop, len(m.sortedContent))
-		colSizes    = m.computeColSizes()
-	)
+	return all
+}
 
-	var (
-		headers string
-		table   string
-	)
+// SetBottomTextFunc() nastaví funkci pro vlastní text ve spodním okraji, viz
+// WithBottomTextFunc()
+// Pro zrušení předat nil
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetBottomTextFunc(f BottomTextFunc) TableModel {
+	m.bottomTextFunc = f
 
-	for i, h := range m.headers {
-		if i > 0 && i < len(m.headers) {
-			headers = lipgloss.JoinHorizontal(
-				lipgloss.Left,
-				headers,
-				m.headerStyle.Render(m.borderType.Right),
-			)
-		}
+	return m
+}
 
-		if m.sortByCol == i {
-			switch m.sortOrder {
-			case SortAscendig:
-				h = " " + h
-			case SortDescending:
-				h = " " + h
-			}
-		}
+// SetRowStyleFunc() nastaví funkci pro styl řádků podle jejich obsahu, viz WithRowStyleFunc()
+// Pro zrušení předat nil
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetRowStyleFunc(f RowStyleFunc) TableModel {
+	m.rowStyleFunc = f
+	m.rev = lastRev.Add(1)
 
-		stripCol := stripansi.Strip(h)
-		hLen := len([]rune(stripCol))
-		if hLen > colSizes[i] {
-			if hLen > 3 {
-				h = string([]rune(stripCol)[:colSizes[i]-1]) + "…"
-			}
-		}
+	return m
+}
 
-		headers = lipgloss.JoinHorizontal(
-			lipgloss.Left,
-			headers,
-			m.headerStyle.Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(h),
-		)
+// GetVisibleColumns() vrátí indexy aktuálně zobrazených sloupečků
+func (m TableModel) GetVisibleColumns() []int {
+	cols, _ := m.layoutColumns()
+
+	return cols
+}
+
+// ScrollToColumn() posune vodorovně tabulku tak, aby byl sloupeček col vidět
+// Má vliv jen s WithHorizontalScroll()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ScrollToColumn(col int) TableModel {
+	if len(m.headers) == 0 {
+		return m
 	}
 
-	if m.filterInputDisplayed {
-		headers = lipgloss.JoinVertical(
-			lipgloss.Top, m.filterInput.View(), headers,
-		)
-	} else if m.filter != "" {
-		filter := m.filter
-		l := len([]rune(filter))
-		width := m.width - 10
-		if l > width {
-			filter = string([]rune(m.filter)[:width-1]) + "…"
-		}
-		headers = lipgloss.JoinVertical(
-			lipgloss.Top,
-			m.filterStyle.Width(m.width-2).Render(" Filtr: "+filter),
-			headers,
-		)
+	col = min(max(col, 0), len(m.headers)-1)
+
+	return m.scrollToPosition(m.columnPosition(col))
+}
+
+// scrollToPosition() posune vodorovně tabulku tak, aby byl vidět sloupeček na
+// pozici pos v pořadí zobrazení
+func (m TableModel) scrollToPosition(pos int) TableModel {
+	pos = min(max(pos, 0), len(m.headers)-1)
+
+	if pos < m.colOffset {
+		m.colOffset = pos
+		return m
 	}
 
-	selectedLine := m.selectedLine - m.scrolledTop
-	for lineNum, line := range m.sortedContent[m.scrolledTop:linesHeight] {
-		if lineNum > linesHeight {
+	for m.colOffset < pos {
+		cols, _ := m.layoutColumns()
+		if m.columnPosition(cols[len(cols)-1]) >= pos {
 			break
 		}
-		var tl string
+		m.colOffset++
+	}
 
-		style := m.linesStyle
-		if lineNum == selectedLine {
-			style = m.selectedLineStyle
-		}
+	return m
+}
 
-		for i, col := range line {
-			if i > 0 && i < len(m.headers) {
-				tl = lipgloss.JoinHorizontal(
-					lipgloss.Left,
-					tl,
-					style.Render(m.borderType.Right),
-				)
-			}
-			stripCol := stripansi.Strip(col)
-			if len([]rune(stripCol)) > colSizes[i] {
-				col = string([]rune(stripCol)[:colSizes[i]-1]) + "…"
-			}
-			tl = lipgloss.JoinHorizontal(
-				lipgloss.Left,
-				tl,
-				style.Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(col),
-			)
-		}
-		if lineNum == 0 {
-			table = tl
-		} else {
-			table = lipgloss.JoinVertical(lipgloss.Top, table, tl)
-		}
+// MoveColumn() přesune sloupeček z pozice from na pozici to (pozice v pořadí zobrazení)
+// Šířky sloupečků, řazení i filtrování zůstávají u přesunutého sloupečku,
+// GetContent() vrací řádky stále v původním pořadí sloupečků
+// Pokud je některá pozice mimo rozsah, vrátí model beze změny
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) MoveColumn(from, to int) TableModel {
+	if from < 0 || from >= len(m.headers) || to < 0 || to >= len(m.headers) {
+		return m
 	}
 
-	lines := len(m.sortedContent[m.scrolledTop:linesHeight])
-	if lines < height {
-		var fill string
-		for i := range colSizes {
-			if i > 0 && i < len(m.headers) {
-				fill = lipgloss.JoinHorizontal(
-					lipgloss.Left,
-					fill,
-					m.linesStyle.Render(m.borderType.Right),
-				)
-			}
-			fill = lipgloss.JoinHorizontal(
-				lipgloss.Left,
-				fill,
-				m.linesStyle.Width(colSizes[i]).Inline(true).MaxWidth(colSizes[i]).Render(" "),
-			)
+	order := slices.Clone(m.columnOrder())
+	col := order[from]
+	order = slices.Delete(order, from, from+1)
+	order = slices.Insert(order, to, col)
+
+	m.colOrder = order
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// SetColumnOrder() nastaví pořadí zobrazení sloupečků, order obsahuje indexy
+// sloupečků v pořadí, v jakém se mají zobrazit
+// Pokud order není permutace všech sloupečků, vrátí model beze změny
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetColumnOrder(order []int) TableModel {
+	if len(order) != len(m.headers) {
+		return m
+	}
+
+	seen := make([]bool, len(order))
+	for _, col := range order {
+		if col < 0 || col >= len(order) || seen[col] {
+			return m
 		}
+		seen[col] = true
+	}
 
-		for i := range height - lines - 3 {
-			if len(m.sortedContent) > 0 {
-				table = lipgloss.JoinVertical(lipgloss.Top, table, fill)
-			} else {
-				table += fill
-				if i < (height - lines - 4) {
-					table += "\n"
-				}
-			}
+	m.colOrder = slices.Clone(order)
+	m.rev = lastRev.Add(1)
+
+	return m
+}
+
+// GetColumnOrder() vrátí indexy sloupečků v pořadí zobrazení
+func (m TableModel) GetColumnOrder() []int {
+	return slices.Clone(m.columnOrder())
+}
+
+// GetContentDisplayOrder() vrátí celý obsah se sloupečky v pořadí zobrazení
+func (m TableModel) GetContentDisplayOrder() [][]string {
+	order := m.columnOrder()
+
+	rows := make([][]string, len(m.content))
+	for i, line := range m.content {
+		row := make([]string, len(order))
+		for p, col := range order {
+			row[p] = cellAt(line, col)
 		}
+		rows[i] = row
 	}
 
-	s = lipgloss.JoinVertical(
-		lipgloss.Top, headers, table,
-	)
+	return rows
+}
 
-	s = m.addBorders(s)
+// SetFooter() nastaví patičku tabulky, pro skrytí patičky nic nepředávat
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetFooter(cells ...string) TableModel {
+	m.footer = cells
 
-	return s
+	return m.fitViewport()
+}
+
+// SetFooterFunc() nastaví funkci, která spočítá patičku z řádků tabulky, viz WithFooterFunc()
+// Pro zrušení předat nil, poslední spočítaná patička pak zůstane nastavená
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetFooterFunc(f FooterFunc) TableModel {
+	m.footerFunc = f
+	if f != nil {
+		m.footer = f(m.filteredContent)
+	}
 
+	return m.fitViewport()
 }
 
-func (m TableModel) addBorders(table string) string {
-	contentLength := len(m.sortedContent)
+// GetFooter() vrátí buňky patičky
+func (m TableModel) GetFooter() []string {
+	return m.footer
+}
 
-	borderTop := m.borderType.TopLeft
-	if m.title == "" {
-		borderTop += strings.Repeat(m.borderType.Top, m.width-2)
-		borderTop += m.borderStyle.Render(m.borderType.TopRight)
-	} else {
-		t := m.title
-		if len([]rune(m.title)) > m.width-4 {
-			t = m.title[:m.width-7] + "..."
-		}
+// SetScrollBar() nastaví, kdy se zobrazuje scrollbar, viz WithScrollBar()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetScrollBar(mode ScrollBarMode) TableModel {
+	m.scrollBar = mode
+	m.filterInput.Width = m.innerWidth() - 9
+	m.rev = lastRev.Add(1)
 
-		o := len([]rune(t)) % 2
-		borderTop += strings.Repeat(
-			m.borderType.Top,
-			((m.width-1)/2)-(len([]rune(t))/2)-1,
-		)
-		borderTop += "[" + m.titleStyle.Render(t) + m.borderStyle.Render("]")
-		borderTop += m.borderStyle.Render(strings.Repeat(
-			m.borderType.Top,
-			m.width-((m.width-1)/2)-(len([]rune(t))/2)-3-o,
-		))
-		borderTop += m.borderStyle.Render(m.borderType.TopRight)
+	return m
+}
+
+// SetPercentIndicator() zapne/vypne zobrazení procent posunu, viz WithPercentIndicator()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetPercentIndicator(show bool) TableModel {
+	m.percentIndicator = show
+
+	return m
+}
+
+// PinRow() připne řádek obsahu s indexem index (index v GetContent()) k okraji edge
+// Připnutý řádek se neposouvá, je zobrazený vždy pod headery (EdgeTop) nebo nad
+// patičkou (EdgeBottom) a zabírá jeden řádek terminálu, kurzorem jde vybrat
+// Připnuté řádky se dál filtrují a mezi sebou řadí, s WithKeyColumn() vydrží SetContent()
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) PinRow(index int, edge Edge) TableModel {
+	if index < 0 || index >= len(m.content) {
+		return m
+	}
+	if e, ok := m.pinned[index]; ok && e == edge {
+		return m
 	}
-	borderTop = m.borderStyle.Render(borderTop)
 
-	borderLeft := strings.Repeat(m.borderType.Left+"\n", m.height-3)
-	borderLeft += m.borderType.Left
-	borderLeft = m.borderStyle.Render(borderLeft)
+	selected := m.selectedContentIndex()
+
+	m.pinned = maps.Clone(m.pinned)
+	if m.pinned == nil {
+		m.pinned = make(map[int]Edge)
+	}
+	m.pinned[index] = edge
+	m = m.refreshContent()
 
-	height := m.height - 3
+	return m.reselect(selected, m.selectedLine)
+}
 
-	var borderRight string
-	if contentLength <= height {
-		borderRight = strings.Repeat(
-			m.borderStyle.Render(m.borderType.Right)+"\n",
-			height,
-		)
-		borderRight += m.borderStyle.Render(m.borderType.Right)
-	} else {
-		s := m.scrolledTop / ((contentLength - 1) / (height - 1))
+// UnpinRow() zruší připnutí řádku obsahu s indexem index (index v GetContent())
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) UnpinRow(index int) TableModel {
+	if _, ok := m.pinned[index]; !ok {
+		return m
+	}
 
-		borderRight += m.borderStyle.Render(m.borderType.Right) + "\n"
+	selected := m.selectedContentIndex()
 
-		if m.scrolledTop > contentLength-height-1 {
-			borderRight += strings.Repeat(m.scrollBarStyleSpace.Render("░")+"\n", height-1)
-			borderRight += m.scrollBarStyleBar.Render("█")
-		} else {
-			for l := range height {
-				if s == l {
-					borderRight += m.scrollBarStyleBar.Render("█")
-				} else {
-					borderRight += m.scrollBarStyleSpace.Render("░")
-				}
-				if l < height-1 {
-					borderRight += "\n"
-				}
-			}
-		}
+	m.pinned = maps.Clone(m.pinned)
+	delete(m.pinned, index)
+	m = m.refreshContent()
+
+	return m.reselect(selected, m.selectedLine)
+}
+
+// GetPinnedRows() vrátí seřazené indexy připnutých řádků (indexy v GetContent())
+func (m TableModel) GetPinnedRows() []int {
+	return slices.Sorted(maps.Keys(m.pinned))
+}
+
+// SetPage() přejde na stránku n (číslováno od 0), viz WithPagination()
+// Vybraný řádek zůstane na stejném místě stránky, na poslední stránce nejvýše
+// na jejím posledním řádku, stránka mimo rozsah se omezí
+// Bez stránkování nic nedělá
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) SetPage(n int) TableModel {
+	if !m.pagination {
+		return m
 	}
 
-	var borderBottom string
-	if contentLength == 0 {
-		borderBottom = m.borderType.BottomLeft
-		borderBottom += strings.Repeat(m.borderType.Bottom, m.width-2)
-		borderBottom += m.borderType.BottomRight
-	} else if contentLength <= height {
-		borderBottom += fmt.Sprintf("[%d/%d]", m.selectedLine+1, len(m.sortedContent)) + m.borderType.Bottom
-		borderBottom = m.borderType.BottomLeft +
-			strings.Repeat(m.borderType.Bottom, m.width-len(borderBottom)) +
-			borderBottom +
-			m.borderType.BottomRight
-	} else {
-		var p float64
-		if m.scrolledTop >= contentLength-height {
-			p = 100
-		} else {
-			p = (float64(m.scrolledTop) / float64(contentLength-1)) * 100
+	m = m.clampPosition()
+	n = min(max(n, 0), m.GetPageCount()-1)
+
+	offset := 0
+	if !m.isPinnedLine(m.selectedLine) {
+		offset = m.selectedLine - m.scrolledTop
+	}
+
+	start := m.pageStart(n)
+	m.scrolledTop = start
+	if m.bodyEnd() > m.bodyStart() {
+		line := min(start+offset, m.bodyEnd()-1, start+m.pageSize()-1)
+		if m.isGroupHeader(line) {
+			line = m.nearestDataRow(line, 1)
 		}
+		if line >= 0 {
+			m.selectedLine = line
+		}
+	}
 
-		borderBottom = fmt.Sprintf("[%.0f%%]", p) + m.borderType.Bottom
-		borderBottom += fmt.Sprintf("[%d/%d]", m.selectedLine+1, len(m.sortedContent))
-		borderBottom = m.borderType.BottomLeft +
-			strings.Repeat(m.borderType.Bottom, m.width-1-len(borderBottom)) +
-			borderBottom +
-			m.borderType.Bottom +
-			m.borderType.BottomRight
+	return m.clampPosition()
+}
+
+// GetPage() vrátí aktuální stránku (číslováno od 0), viz WithPagination()
+// Bez stránkování vrátí 0
+func (m TableModel) GetPage() int {
+	if !m.pagination {
+		return 0
 	}
-	borderBottom = m.borderStyle.Render(borderBottom)
 
-	ret := lipgloss.JoinHorizontal(lipgloss.Left, borderLeft, table, borderRight)
-	ret = lipgloss.JoinVertical(lipgloss.Left, borderTop, ret)
-	ret = lipgloss.JoinVertical(lipgloss.Left, ret, borderBottom)
+	return m.pageOf(m.clampPosition().scrolledTop)
+}
 
-	return ret
+// GetPageCount() vrátí počet stránek (alespoň 1), viz WithPagination()
+// Bez stránkování vrátí 1
+func (m TableModel) GetPageCount() int {
+	if !m.pagination {
+		return 1
+	}
+
+	size := m.pageSize()
+
+	return max((m.bodyEnd()-m.bodyStart()+size-1)/size, 1)
 }
 
-// Sort() seřadí tabulku podle sloupečku col a ve směru dir
-// Pro zrušení řazení předat do dir NoSort
+// pageSize() vrátí počet řádků na stránce
+func (m TableModel) pageSize() int {
+	return max(m.viewportRows(), 1)
+}
+
+// pageOf() vrátí stránku, na které je zobrazený řádek line
+func (m TableModel) pageOf(line int) int {
+	return max(line-m.bodyStart(), 0) / m.pageSize()
+}
+
+// pageStart() vrátí první zobrazený řádek stránky n
+func (m TableModel) pageStart(n int) int {
+	return m.bodyStart() + n*m.pageSize()
+}
+
+// SetSelectedColumn() nastaví kurzor sloupečku na sloupeček col (index v headerech),
+// viz WithColumnCursor(), s WithHorizontalScroll(true) posune pohled na sloupeček
+// Index mimo rozsah se omezí na první/poslední sloupeček
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) Sort(col int, dir SortOrder) TableModel {
-	if col > len(m.headers)-1 {
+func (m TableModel) SetSelectedColumn(col int) TableModel {
+	if len(m.headers) == 0 {
 		return m
 	}
-	m.sortByCol = col
-	m.sortOrder = dir
 
-	if dir == SortUnsorted {
-		m.sortedContent = m.filteredContent
-	} else {
-		m.sortedContent = m.sortFilteredContent()
+	m.selectedCol = min(max(col, 0), len(m.headers)-1)
+	if m.horizontalScroll {
+		m = m.ScrollToColumn(m.selectedCol)
 	}
 
 	return m
 }
 
-// GetSorting() vrátí sloupeček, podle kterého se řadí a směř řazení
-func (m TableModel) GetSorting() (sortCol int, sortOder SortOrder) {
-	return m.sortByCol, m.sortOrder
+// GetSelectedColumn() vrátí index sloupečku (v headerech) pod kurzorem sloupečku
+// Pokud není zapnutý kurzor sloupečku (WithColumnCursor()), vrátí -1
+func (m TableModel) GetSelectedColumn() int {
+	return m.selectedColumn()
 }
 
-// SetFilter() nastaví filtr na tabulce
-// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetFilter(filter string) TableModel {
-	m.filter = filter
-	m.filteredContent = m.filterContent()
-	m.sortedContent = m.sortFilteredContent()
-	m.scrolledTop = 0
-	m.selectedLine = 0
+// GetSelectedCell() vrátí hodnotu vybrané buňky (průsečík vybraného řádku
+// a sloupečku), viz WithColumnCursor()
+// Pokud není vybraný řádek nebo není zapnutý kurzor sloupečku, vrací ok == false
+func (m TableModel) GetSelectedCell() (value string, ok bool) {
+	i, col := m.selectedContentIndex(), m.selectedColumn()
+	if i < 0 || col < 0 {
+		return "", false
+	}
 
-	return m
+	return cellAt(m.content[i], col), true
 }
 
-// GetFilter() vrátí nastavený filtr na tabulce
+// selectedColumn() vrátí platný index sloupečku pod kurzorem, bez kurzoru -1
+func (m TableModel) selectedColumn() int {
+	if !m.columnCursor || len(m.headers) == 0 {
+		return -1
+	}
+
+	return min(max(m.selectedCol, 0), len(m.headers)-1)
+}
+
+// SetGroupBy() seskupí řádky podle hodnoty sloupečku col, viz WithGroupBy()
+// Pro zrušení seskupení předat col < 0
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) GetFilter() string {
-	return m.filter
+func (m TableModel) SetGroupBy(col int) TableModel {
+	selected := m.selectedContentIndex()
+
+	m.groupBy = col
+	m.collapsed = nil
+	m = m.refreshContent()
+
+	return m.reselect(selected, m.selectedLine)
 }
 
-// SetHeaders() nastaví nové headery
+// SetGroupCollapsed() sbalí/rozbalí skupinu s názvem name (hodnota sloupečku
+// z WithGroupBy()), řádky sbalené skupiny se nezobrazují
+// Pokud byl vybraný řádek ve sbalené skupině, vybere se následující zobrazený řádek
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetHeaders(headers ...string) TableModel {
-	m.headers = headers
+func (m TableModel) SetGroupCollapsed(name string, collapsed bool) TableModel {
+	if m.collapsed[name] == collapsed {
+		return m
+	}
 
-	return m
+	selected := m.selectedContentIndex()
+
+	m.collapsed = maps.Clone(m.collapsed)
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	if collapsed {
+		m.collapsed[name] = true
+	} else {
+		delete(m.collapsed, name)
+	}
+	m = m.refreshContent()
+
+	line := m.selectedLine
+	for l, row := range m.sortedContent {
+		if m.isGroupHeader(l) && row[0] == name {
+			line = l
+			break
+		}
+	}
+
+	return m.reselect(selected, line)
 }
 
-// SetContent() nastaví nové řádky, starý obsah zahodí
+// ToggleGroup() sbalí/rozbalí skupinu s názvem name, viz SetGroupCollapsed()
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetContent(rows ...[]string) TableModel {
-	m.content = rows
-	m.filteredContent = m.filterContent()
-	m.sortedContent = m.sortFilteredContent()
+func (m TableModel) ToggleGroup(name string) TableModel {
+	return m.SetGroupCollapsed(name, !m.collapsed[name])
+}
+
+// ToggleGroupAtCursor() sbalí/rozbalí skupinu u kurzoru
+// Skupina u kurzoru je sbalená skupina těsně nad skupinou vybraného řádku nebo
+// těsně pod vybraným řádkem (takže druhý stisk ToggleGroup vrátí sbalení zpět),
+// jinak skupina vybraného řádku
+// Bez seskupení nebo bez zobrazených řádků nic nedělá
+// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TableModel) ToggleGroupAtCursor() TableModel {
+	name, ok := m.cursorGroup()
+	if !ok {
+		return m
+	}
+
+	return m.ToggleGroup(name)
+}
+
+// cursorGroup() vrátí název skupiny u kurzoru, viz ToggleGroupAtCursor()
+func (m TableModel) cursorGroup() (string, bool) {
+	line := m.selectedLine
+	if m.groupBy < 0 || line < 0 || line >= len(m.sortedContent) {
+		return "", false
+	}
+	if m.isGroupHeader(line) {
+		return m.sortedContent[line][0], true
+	}
+
+	header := line
+	for header >= 0 && !m.isGroupHeader(header) {
+		header--
+	}
+	if header < 0 {
+		return "", false
+	}
+
+	for _, l := range []int{header - 1, line + 1} {
+		if m.isGroupHeader(l) && m.collapsed[m.sortedContent[l][0]] {
+			return m.sortedContent[l][0], true
+		}
+	}
 
-	return m
+	return m.sortedContent[header][0], true
 }
 
-// SetColSizes() nastaví šířku sloupečků
-// Počet hodnot musí být stejný jako počet sloupečků
-// Pokud je velikost == 0, tak je použita automatická velikost
+// ExpandAllGroups() rozbalí všechny skupiny
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetColSizes(s ...int) TableModel {
-	m.colSizes = s
+func (m TableModel) ExpandAllGroups() TableModel {
+	if len(m.collapsed) == 0 {
+		return m
+	}
 
-	return m
-}
+	selected := m.selectedContentIndex()
 
-// AppendContent() přidá další řádky
-// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) AppendContent(rows ...[]string) TableModel {
-	m.content = append(m.content, rows...)
-	m.filteredContent = m.filterContent()
-	m.sortedContent = m.sortFilteredContent()
+	m.collapsed = nil
+	m = m.refreshContent()
 
-	return m
+	return m.reselect(selected, m.selectedLine)
 }
 
-// GetContent() vrátí celý obsah, i když je nastavený filter
-func (m TableModel) GetContent() [][]string {
-	return m.content
+// GetCollapsedGroups() vrátí seřazené názvy sbalených skupin
+func (m TableModel) GetCollapsedGroups() []string {
+	return slices.Sorted(maps.Keys(m.collapsed))
 }
 
-// GetFilteredContent() vrátí obsah, pokud je nastavený filtr, tak filtrovaný
-func (m TableModel) GetFilteredContent() [][]string {
-	return m.filteredContent
+// GetSelectedRow() vrátí vybraný řádek, nikdy ne řádek skupiny
+// Pokud není vybraný žádný řádek, vrátí nil
+func (m TableModel) GetSelectedRow() []string {
+	if m.selectedContentIndex() < 0 {
+		return nil
+	}
+
+	return m.sortedContent[m.selectedLine]
 }
 
-// SetSize() nastaví velikost okna
+// SetPosition() nastaví pozici levého horního rohu tabulky na obrazovce, viz WithPosition()
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetSize(width, height int) TableModel {
-	m.width, m.height = width, height
-	m.filterInput.Width = m.width - 11
+func (m TableModel) SetPosition(x, y int) TableModel {
+	m.posX, m.posY = x, y
 
 	return m
 }
 
-// SetSelectedLine() nastaví vybraný řádek
+// SetLoading() zapne/vypne stav načítání
+// Při načítání se místo řádků zobrazuje animace s textem (WithLoadingText())
+// a klávesy i myš se posílají dál bez zpracování
+// Obsah, vybraný řádek ani posun se nemění, po vypnutí načítání se zobrazí jako předtím
+// Animaci spustí tea.Cmd, který vrátí následující volání Update()
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetSelectedLine(line int) TableModel {
-	if line < len(m.filteredContent) && line >= 0 {
-		m.selectedLine = line
+func (m TableModel) SetLoading(loading bool) TableModel {
+	if loading == m.loading {
+		return m
+	}
 
-		if m.selectedLine >= m.scrolledTop+(m.height-4) {
-			if m.filter == "" {
-				m.scrolledTop = m.selectedLine - (m.height - 4)
-			} else {
-				m.scrolledTop = m.selectedLine - (m.height - 5)
-			}
-		} else if m.selectedLine < m.scrolledTop {
-			m.scrolledTop = m.selectedLine
-		}
+	m.loading = loading
+	m.pendingTick = loading
+	if loading {
+		m.loadingTag++
+		m.loadingFrame = 0
 	}
 
 	return m
 }
 
-// GetSelectedLine() vrátí index aktuálně vybraného řádku
-func (m TableModel) GetSelectedLine() int {
-	return m.selectedLine
+// IsLoading() vrátí true, pokud je tabulka ve stavu načítání
+func (m TableModel) IsLoading() bool {
+	return m.loading
 }
 
-// SelectLastLine() nastaví vybraný řádek na poslední
+// SetTitle() nastaví titulek tabulky, pokud je nastaveno na "" tak se nezobrazuje vůbec
 // Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SelectLastLine() TableModel {
-	m = m.SetSelectedLine(len(m.filteredContent) - 1)
+func (m TableModel) SetTitle(title string) TableModel {
+	m.title = title
 
 	return m
 }
 
-// ViewScroll() posune pohled o num řádků dolů/nahoru
-// Neposunuje aktuálně vybraný řádek
-// Pokud je num < 0, posouvá pohled nahoru o num řádků
-// Pokud je num > 0, posouvá pohled dolů o num řádků
-// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) ViewScroll(num int) TableModel {
-	if num > 0 {
-		if m.scrolledTop+num <= len(m.filteredContent)-m.height+3 {
-			m.scrolledTop += num
-		}
-	} else if num < 0 {
-		if m.scrolledTop+num >= 0 {
-			m.scrolledTop += num
+// refreshContent() je interní funkce, která znovu přefiltruje a seřadí obsah
+// volá se při změně obsahu, filtru nebo řazení
+func (m TableModel) refreshContent() TableModel {
+	m.filteredContent, m.filteredIndex = m.filterContent()
+	m.sortedContent, m.sortedIndex, m.pinnedTop, m.pinnedBottom = m.pinRows(m.sortFilteredContent())
+	if m.isGroupHeader(m.selectedLine) {
+		if line := m.nearestDataRow(m.selectedLine, 1); line >= 0 {
+			m.selectedLine = line
 		}
 	}
 
+	if m.footerFunc != nil {
+		m.footer = m.footerFunc(m.filteredContent)
+	}
+
+	m.natural = m.measureColSizes()
+	m.rev = lastRev.Add(1)
+
 	return m
 }
 
-// PageScroll() posune pohled o stránku dolů/nahoru
-// Pokud je moveSelected == true, posune i aktuálně vybraný řádek
-// Pokud je num < 0, posune pohled o num stránek nahoru
-// Pokud je num > 0, posune pohled o num stránek dolů
-// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) PageScroll(num int, moveSelected bool) TableModel {
-	height := m.height
-	if m.filter != "" {
-		height--
+// pinRows() přesune připnuté řádky seřazeného content (s indexy idx) na začátek
+// a konec, zbytek seskupí přes groupRows()
+// Vrací řádky, jejich indexy a počty řádků připnutých nahoře a dole
+func (m TableModel) pinRows(content [][]string, idx []int) ([][]string, []int, int, int) {
+	if len(m.pinned) == 0 {
+		content, idx = m.groupRows(content, idx)
+		return content, idx, 0, 0
 	}
 
-	m.scrolledTop += (height - 3) * num
-	if num > 0 {
-		if moveSelected {
-			m.selectedLine = m.scrolledTop + height - 4
+	var (
+		top, body, bottom          [][]string
+		topIdx, bodyIdx, bottomIdx []int
+	)
+	for p, i := range idx {
+		edge, ok := m.pinned[i]
+		switch {
+		case !ok:
+			body = append(body, content[p])
+			bodyIdx = append(bodyIdx, i)
+		case edge == EdgeBottom:
+			bottom = append(bottom, content[p])
+			bottomIdx = append(bottomIdx, i)
+		default:
+			top = append(top, content[p])
+			topIdx = append(topIdx, i)
 		}
-	} else if num < 0 {
-		if moveSelected {
-			m.selectedLine = m.scrolledTop
+	}
+	body, bodyIdx = m.groupRows(body, bodyIdx)
+
+	return slices.Concat(top, body, bottom), slices.Concat(topIdx, bodyIdx, bottomIdx), len(top), len(bottom)
+}
+
+// bodyStart() vrátí první zobrazený řádek, který se posouvá (první za řádky
+// připnutými nahoře)
+func (m TableModel) bodyStart() int {
+	return m.pinnedTop
+}
+
+// bodyEnd() vrátí zobrazený řádek za posledním řádkem, který se posouvá (první
+// z řádků připnutých dole)
+func (m TableModel) bodyEnd() int {
+	return len(m.sortedContent) - m.pinnedBottom
+}
+
+// isPinnedLine() vrátí true, pokud je zobrazený řádek line připnutý, viz PinRow()
+func (m TableModel) isPinnedLine(line int) bool {
+	return line >= 0 && line < len(m.sortedContent) && (line < m.bodyStart() || line >= m.bodyEnd())
+}
+
+// groupRows() seskupí seřazené řádky content (s indexy idx) podle sloupečku groupBy
+// Před každou skupinu vloží řádek skupiny {název, počet řádků} s indexem -1,
+// řádky sbalených skupin vynechá
+func (m TableModel) groupRows(content [][]string, idx []int) ([][]string, []int) {
+	if m.groupBy < 0 {
+		return content, idx
+	}
+
+	var (
+		order   []string
+		members = make(map[string][]int)
+	)
+	for p, row := range content {
+		g := cellAt(row, m.groupBy)
+		if _, ok := members[g]; !ok {
+			order = append(order, g)
 		}
+		members[g] = append(members[g], p)
 	}
 
-	if m.scrolledTop < 0 {
-		m.scrolledTop = 0
-		if moveSelected {
-			m.selectedLine = m.scrolledTop
+	var (
+		grouped    = make([][]string, 0, len(content)+len(order))
+		groupedIdx = make([]int, 0, len(content)+len(order))
+	)
+	for _, g := range order {
+		grouped = append(grouped, []string{g, strconv.Itoa(len(members[g]))})
+		groupedIdx = append(groupedIdx, -1)
+
+		if m.collapsed[g] {
+			continue
+		}
+		for _, p := range members[g] {
+			grouped = append(grouped, content[p])
+			groupedIdx = append(groupedIdx, idx[p])
 		}
 	}
 
-	lenContent := len(m.filteredContent)
-	if m.scrolledTop > lenContent-height-3 {
-		m.scrolledTop = lenContent - height + 3
-		m.scrolledTop = max(m.scrolledTop, 0)
-		if moveSelected {
-			m.selectedLine = m.scrolledTop + height - 4
-			if m.selectedLine > lenContent {
-				m.selectedLine = lenContent - 1
+	return grouped, groupedIdx
+}
+
+// isGroupHeader() vrátí true, pokud je zobrazený řádek line řádek skupiny
+func (m TableModel) isGroupHeader(line int) bool {
+	return line >= 0 && line < len(m.sortedIndex) && m.sortedIndex[line] < 0
+}
+
+// nearestDataRow() vrátí nejbližší zobrazený řádek od line ve směru dir (1 dolů,
+// -1 nahoru), který není řádek skupiny, pokud ve směru žádný není, hledá opačně
+// Pokud žádný takový řádek není, vrátí -1
+func (m TableModel) nearestDataRow(line, dir int) int {
+	for _, d := range []int{dir, -dir} {
+		for l := line; l >= 0 && l < len(m.sortedIndex); l += d {
+			if !m.isGroupHeader(l) {
+				return l
 			}
 		}
 	}
 
-	return m
+	return -1
 }
 
-// SetTitle() nastaví titulek tabulky, pokud je nastaveno na "" tak se nezobrazuje vůbec
-// Vrací TableModel, který je potřeba přiřadit/přepsat v hlavním modelu
-func (m TableModel) SetTitle(title string) TableModel {
-	m.title = title
+// dataPosition() vrátí pořadí vybraného řádku a počet zobrazených řádků bez řádků skupin
+func (m TableModel) dataPosition() (position, total int) {
+	for line, i := range m.sortedIndex {
+		if i < 0 {
+			continue
+		}
+		total++
+		if line <= m.selectedLine {
+			position++
+		}
+	}
 
-	return m
+	return position, total
+}
+
+// viewGroupHeader() vykreslí řádek skupiny přes celou šířku tabulky
+func (m TableModel) viewGroupHeader(line int) string {
+	var (
+		width  = max(m.innerWidth(), 0)
+		header = m.sortedContent[line]
+		symbol = "▾"
+	)
+	if m.collapsed[header[0]] {
+		symbol = "▸"
+	}
+
+	text := truncate(fmt.Sprintf("%s %s (%s)", symbol, header[0], header[1]), width)
+
+	return m.groupStyle.Width(width).MaxWidth(width).Inline(true).Render(text)
 }
 
-func (m TableModel) sortFilteredContent() [][]string {
-	s := make([][]string, len(m.filteredContent))
-	copy(s, m.filteredContent)
+// sortFilteredContent() vrátí seřazený filtrovaný obsah a k němu indexy řádků v content
+func (m TableModel) sortFilteredContent() ([][]string, []int) {
+	idx := make([]int, len(m.filteredIndex))
+	copy(idx, m.filteredIndex)
 
 	if m.sortOrder != SortUnsorted {
-		c := collate.New(language.Czech)
-		slices.SortFunc(s, func(a, b []string) int {
+		compare := m.colCompares[m.sortByCol]
+		if compare == nil {
+			compare = collate.New(language.Czech).CompareString
+		}
+		slices.SortStableFunc(idx, func(a, b int) int {
+			x, y := cellAt(m.content[a], m.sortByCol), cellAt(m.content[b], m.sortByCol)
 			switch m.sortOrder {
 			case SortAscendig:
-				return c.CompareString(a[m.sortByCol], b[m.sortByCol])
+				return compare(x, y)
 			case SortDescending:
-				return c.CompareString(b[m.sortByCol], a[m.sortByCol])
+				return compare(y, x)
 			default:
 				return 0
 			}
 		})
 	}
 
-	return s
+	s := make([][]string, len(idx))
+	for i, ci := range idx {
+		s[i] = m.content[ci]
+	}
+
+	return s, idx
 }
 
-func (m TableModel) filterContent() [][]string {
+// filterContent() vrátí řádky odpovídající filtru a k nim indexy řádků v content
+func (m TableModel) filterContent() ([][]string, []int) {
 	if m.filter == "" {
-		return m.content
+		idx := make([]int, len(m.content))
+		for i := range idx {
+			idx[i] = i
+		}
+		return m.content, idx
+	}
+
+	if m.fuzzyFilter && m.filterScorer != nil {
+		return m.fuzzyFilterContent()
 	}
 
-	var ret [][]string
+	var (
+		ret [][]string
+		idx []int
+	)
 
 	filter := strings.ToLower(m.filter)
 
-	for _, line := range m.content {
+	for lineNum, line := range m.content {
 	line:
 		for _, colN := range m.filterColums {
-			if strings.Contains(strings.ToLower(line[colN]), filter) {
+			if strings.Contains(strings.ToLower(cellAt(line, colN)), filter) {
 				ret = append(ret, line)
+				idx = append(idx, lineNum)
 				break line
 			}
 		}
 	}
-	return ret
+	return ret, idx
+}
+
+// fuzzyFilterContent() vrátí řádky odpovídající filtru podle filterScorer seřazené
+// podle nejlepšího skóre ze všech filtrovaných sloupečků
+func (m TableModel) fuzzyFilterContent() ([][]string, []int) {
+	type scored struct {
+		index int
+		score int
+	}
+
+	var matches []scored
+	for lineNum, line := range m.content {
+		var (
+			best  int
+			found bool
+		)
+		for _, colN := range m.filterColums {
+			score, ok := m.filterScorer.Score(m.filter, stripansi.Strip(cellAt(line, colN)))
+			if ok && (!found || score > best) {
+				best = score
+				found = true
+			}
+		}
+		if found {
+			matches = append(matches, scored{index: lineNum, score: best})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		return b.score - a.score
+	})
+
+	ret := make([][]string, len(matches))
+	idx := make([]int, len(matches))
+	for i, match := range matches {
+		ret[i] = m.content[match.index]
+		idx[i] = match.index
+	}
+
+	return ret, idx
+}
+
+// layoutColumns() vrátí indexy zobrazených sloupečků v pořadí zobrazení a šířky
+// všech sloupečků (indexované podle sloupečku)
+func (m TableModel) layoutColumns() ([]int, []int) {
+	if m.horizontalScroll {
+		return m.scrolledColumns()
+	}
+
+	return m.columnOrder(), m.computeColSizes()
+}
+
+// columnOrder() vrátí indexy sloupečků v pořadí zobrazení
+func (m TableModel) columnOrder() []int {
+	if len(m.colOrder) == len(m.headers) {
+		return m.colOrder
+	}
+
+	order := make([]int, len(m.headers))
+	for i := range order {
+		order[i] = i
+	}
+
+	return order
+}
+
+// columnPosition() vrátí pozici sloupečku col v pořadí zobrazení
+func (m TableModel) columnPosition(col int) int {
+	if len(m.colOrder) != len(m.headers) {
+		return col
+	}
+
+	return slices.Index(m.colOrder, col)
+}
+
+// scrolledColumns() je layoutColumns() pro WithHorizontalScroll()
+// Od colOffset přidává sloupečky, dokud se vejdou v požadované šířce, a volné
+// místo rozdělí mezi ně
+func (m TableModel) scrolledColumns() ([]int, []int) {
+	colSizes := make([]int, len(m.headers))
+	if len(m.headers) == 0 {
+		return nil, colSizes
+	}
+
+	available := m.innerWidth() - m.prefixWidth()
+
+	desired := m.naturalColSizes()
+	for i := range desired {
+		if size := sizeAt(m.colSizes, i); size != 0 {
+			desired[i] = size
+			continue
+		}
+		desired[i] = max(desired[i], sizeAt(m.colMinSizes, i))
+		if maxSize := m.colMaxSize(i); maxSize != 0 {
+			desired[i] = min(desired[i], maxSize)
+		}
+	}
+
+	var (
+		cols  []int
+		used  int
+		order = m.columnOrder()
+	)
+	for p := min(max(m.colOffset, 0), len(m.headers)-1); p < len(m.headers); p++ {
+		i := order[p]
+		w := desired[i]
+		if len(cols) > 0 {
+			w++
+			if used+w > available {
+				break
+			}
+		}
+		cols = append(cols, i)
+		used += w
+	}
+
+	total := available - (len(cols) - 1)
+
+	var (
+		weights = make([]float64, len(cols))
+		lo      = make([]int, len(cols))
+		hi      = make([]int, len(cols))
+	)
+	for n, i := range cols {
+		weights[n] = float64(desired[i])
+		lo[n] = max(min(desired[i], total), 1)
+		hi[n] = max(total, lo[n])
+		if sizeAt(m.colSizes, i) != 0 {
+			hi[n] = lo[n]
+		} else if maxSize := m.colMaxSize(i); maxSize != 0 {
+			hi[n] = max(maxSize, lo[n])
+		}
+	}
+
+	for n, size := range distributeWidth(total, weights, lo, hi) {
+		colSizes[cols[n]] = size
+	}
+
+	return cols, colSizes
+}
+
+// hiddenColumnIndicators() vrátí šipky pro horní okraj, pokud jsou vlevo nebo
+// vpravo skryté sloupečky
+func (m TableModel) hiddenColumnIndicators() (left, right string) {
+	if !m.horizontalScroll || len(m.headers) == 0 {
+		return "", ""
+	}
+
+	cols, _ := m.layoutColumns()
+	if m.columnPosition(cols[0]) > 0 {
+		left = "◀"
+	}
+	if m.columnPosition(cols[len(cols)-1]) < len(m.headers)-1 {
+		right = "▶"
+	}
+
+	return left, right
+}
+
+// formatCell() vrátí hodnotu sloupečku col v řádku line upravenou formátovačem
+// sloupečku (WithColFormatter()), pokud je nastavený
+func (m TableModel) formatCell(line []string, col int) string {
+	value := cellAt(line, col)
+	if f := m.colFormatters[col]; f != nil {
+		value = f(value)
+	}
+
+	return value
+}
+
+// cellAt() vrátí hodnotu sloupečku col v řádku line, pokud neexistuje, vrátí ""
+func cellAt(line []string, col int) string {
+	if col < 0 || col >= len(line) {
+		return ""
+	}
+
+	return line[col]
+}
+
+// colMaxSize() vrátí maximální šířku automatického sloupečku col podle
+// WithColMaxSizes() a WithMaxColWidth(), 0 pokud není omezená
+func (m TableModel) colMaxSize(col int) int {
+	size := sizeAt(m.colMaxSizes, col)
+	if m.maxColWidth > 0 && (size == 0 || m.maxColWidth < size) {
+		size = m.maxColWidth
+	}
+
+	return size
 }
 
+// computeColSizes() vrátí šířky všech sloupečků
+// Pevné šířky (WithColSizes()) se použijí beze změny, zbylé místo se rozdělí mezi
+// automatické sloupečky - rovnoměrně, při WithFitColumns(true) podle šířky
+// jejich obsahu, nebo podle vah WithColFlex() - s ohledem na WithColMinSizes()
+// a WithColMaxSizes()
 func (m TableModel) computeColSizes() []int {
 	colSizes := make([]int, len(m.headers))
 
-	var fixedSize int
+	available := m.innerWidth() - m.prefixWidth() - (len(m.headers) - 1)
 
+	var auto []int
 	for colNum := range m.headers {
-		if m.colSizes[colNum] != 0 {
-			colSizes[colNum] = m.colSizes[colNum]
-			fixedSize += m.colSizes[colNum]
+		if size := sizeAt(m.colSizes, colNum); size != 0 {
+			colSizes[colNum] = size
+			available -= size
+		} else {
+			auto = append(auto, colNum)
 		}
 	}
 
-	for colNum, hCol := range m.headers {
-		if m.colSizes[colNum] == 0 {
-			l := len([]rune(stripansi.Strip(hCol)))
-			if colSizes[colNum] < l {
-				colSizes[colNum] = l
+	// s poměrnými šířkami mají ostatní automatické sloupečky šířku obsahu
+	// a zbytek místa dostanou sloupečky s vahou
+	flexMode := slices.ContainsFunc(auto, func(colNum int) bool {
+		return sizeAt(m.colFlex, colNum) > 0
+	})
+	if flexMode {
+		natural := m.naturalColSizes()
+
+		var flex []int
+		for _, colNum := range auto {
+			if sizeAt(m.colFlex, colNum) > 0 {
+				flex = append(flex, colNum)
+				continue
 			}
+
+			size := max(natural[colNum], sizeAt(m.colMinSizes, colNum), 1)
+			if hi := m.colMaxSize(colNum); hi > 0 {
+				size = max(min(size, hi), 1)
+			}
+			colSizes[colNum] = size
+			available -= size
 		}
+		auto = flex
 	}
 
-	for _, line := range m.filteredContent {
-		for colNum, col := range line {
-			if m.colSizes[colNum] == 0 {
-				l := len([]rune(stripansi.Strip(col)))
-				if colSizes[colNum] < l {
-					colSizes[colNum] = l
-				}
+	if len(auto) == 0 {
+		return colSizes
+	}
+
+	var (
+		weights = make([]float64, len(auto))
+		lo      = make([]int, len(auto))
+		hi      = make([]int, len(auto))
+	)
+
+	for i, colNum := range auto {
+		weights[i] = 1
+		if flexMode {
+			weights[i] = sizeAt(m.colFlex, colNum)
+		}
+		lo[i] = max(sizeAt(m.colMinSizes, colNum), 1)
+		hi[i] = m.colMaxSize(colNum)
+		if hi[i] == 0 {
+			hi[i] = max(available, lo[i])
+		}
+		hi[i] = max(hi[i], lo[i])
+	}
+
+	if m.fitColumns && !flexMode {
+		natural := m.naturalColSizes()
+
+		var sum int
+		for _, colNum := range auto {
+			sum += natural[colNum]
+		}
+
+		for i, colNum := range auto {
+			if sum <= available {
+				weights[i] = float64(natural[colNum])
+			} else {
+				hi[i] = max(min(hi[i], natural[colNum]), lo[i])
 			}
 		}
 	}
 
-	var varSize, varNum int
-	for i, col := range colSizes {
-		if m.colSizes[i] == 0 {
-			varSize += col
-			varNum++
+	for i, size := range distributeWidth(available, weights, lo, hi) {
+		colSizes[auto[i]] = size
+	}
+
+	return colSizes
+}
+
+// naturalColSizes() vrátí šířku nejdelší hodnoty (včetně headeru) pro každý sloupeček
+// Šířky se měří při změně obsahu (refreshContent()), ne při každém vykreslení
+func (m TableModel) naturalColSizes() []int {
+	if len(m.natural) != len(m.headers) {
+		return m.measureColSizes()
+	}
+
+	return slices.Clone(m.natural)
+}
+
+// measureColSizes() změří šířku nejdelší hodnoty (včetně headeru) pro každý sloupeček
+func (m TableModel) measureColSizes() []int {
+	natural := make([]int, len(m.headers))
+
+	for colNum, hCol := range m.headers {
+		natural[colNum] = max(lipgloss.Width(hCol), 1)
+	}
+
+	for _, line := range m.filteredContent {
+		for colNum := range line {
+			if colNum >= len(natural) {
+				break
+			}
+			natural[colNum] = max(natural[colNum], lipgloss.Width(m.formatCell(line, colNum)))
 		}
 	}
 
-	base := (m.width - (len(m.headers) + 1) - fixedSize) / varNum
-	rem := (m.width - (len(m.headers) + 1) - fixedSize) % varNum
+	return natural
+}
 
-	for i := range colSizes {
-		if m.colSizes[i] == 0 {
-			if rem > 0 {
-				colSizes[i] = base + 1
-				rem--
+// distributeWidth() rozdělí total mezi sloupečky v poměru weights tak, aby každý
+// sloupeček byl v rozmezí lo a hi a součet byl přesně total
+// Pokud to rozmezí neumožňuje, dostane zbytek poslední sloupeček, případně se
+// zmenší nejširší sloupečky (minimálně na šířku 1)
+func distributeWidth(total int, weights []float64, lo, hi []int) []int {
+	n := len(weights)
+	sizes := make([]float64, n)
+	fixed := make([]bool, n)
+
+	for {
+		rem := float64(total)
+		var sumW float64
+		for i := range n {
+			if fixed[i] {
+				rem -= sizes[i]
 			} else {
-				colSizes[i] = base
+				sumW += weights[i]
 			}
 		}
+		if sumW == 0 {
+			break
+		}
+
+		var excess float64
+		for i := range n {
+			if fixed[i] {
+				continue
+			}
+			v := rem * weights[i] / sumW
+			sizes[i] = min(max(v, float64(lo[i])), float64(hi[i]))
+			excess += sizes[i] - v
+		}
+
+		var changed bool
+		for i := range n {
+			if fixed[i] {
+				continue
+			}
+			if excess > 0 && sizes[i] == float64(lo[i]) || excess < 0 && sizes[i] == float64(hi[i]) {
+				fixed[i] = true
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
 	}
 
-	return colSizes
+	ret := make([]int, n)
+	var sum int
+	for i, size := range sizes {
+		ret[i] = int(size)
+		sum += ret[i]
+	}
+
+	for sum < total {
+		best := -1
+		for i := range n {
+			if ret[i] >= hi[i] {
+				continue
+			}
+			if best == -1 || sizes[i]-float64(ret[i]) > sizes[best]-float64(ret[best]) {
+				best = i
+			}
+		}
+		if best == -1 {
+			ret[n-1] += total - sum
+			break
+		}
+		ret[best]++
+		sizes[best] = float64(ret[best])
+		sum++
+	}
+
+	for sum > total {
+		widest := 0
+		for i := range n {
+			if ret[i] >= ret[widest] {
+				widest = i
+			}
+		}
+		if ret[widest] <= 1 {
+			break
+		}
+		ret[widest]--
+		sum--
+	}
+
+	return ret
+}
+
+// sizeAt() vrátí hodnotu sizes[i], pokud index neexistuje, vrátí 0
+func sizeAt[T int | float64](sizes []T, i int) T {
+	if i < 0 || i >= len(sizes) {
+		return 0
+	}
+
+	return sizes[i]
 }
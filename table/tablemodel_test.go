@@ -0,0 +1,590 @@
+package table
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestEmptyText(t *testing.T) {
+	m := NewTableModel(WithHeaders("A", "B")).SetSize(30, 8)
+	if view := ansi.Strip(m.View()); strings.Contains(view, "Žádné") {
+		t.Fatalf("výchozí prázdná tabulka zobrazuje text:\n%s", view)
+	}
+
+	for height := 5; height <= 12; height++ {
+		m := NewTableModel(WithHeaders("A", "B"), WithEmptyText("nic")).SetSize(30, height)
+		view := ansi.Strip(m.View())
+		if !strings.Contains(view, "nic") {
+			t.Fatalf("výška %d: chybí text prázdné tabulky:\n%s", height, view)
+		}
+		if got := len(strings.Split(view, "\n")); got != height {
+			t.Fatalf("výška %d: počet řádků = %d", height, got)
+		}
+	}
+}
+
+func TestMaxRows(t *testing.T) {
+	content := [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}}
+	want := [][]string{{"3"}, {"4"}, {"5"}}
+
+	m := NewTableModel(WithHeaders("N"), WithContent(content...), WithMaxRows(3))
+	if got := m.GetContent(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("WithContent(): obsah = %q, chci %q", got, want)
+	}
+
+	m = NewTableModel(WithHeaders("N"), WithMaxRows(3)).SetContent(content...)
+	if got := m.GetContent(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("SetContent(): obsah = %q, chci %q", got, want)
+	}
+	if m.GetDroppedCount() != 2 {
+		t.Fatalf("GetDroppedCount() = %d, chci 2", m.GetDroppedCount())
+	}
+
+	m = m.InsertRow(3, []string{"6"}).AppendContent([]string{"7"})
+	if got, want := m.GetContent(), [][]string{{"5"}, {"6"}, {"7"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("InsertRow() a AppendContent(): obsah = %q, chci %q", got, want)
+	}
+	if m.GetDroppedCount() != 4 {
+		t.Fatalf("GetDroppedCount() = %d, chci 4", m.GetDroppedCount())
+	}
+}
+
+func TestToggleGroup(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("Namespace", "Pod"),
+		WithContent(
+			[]string{"a", "a1"}, []string{"a", "a2"},
+			[]string{"b", "b1"},
+			[]string{"c", "c1"},
+		),
+		WithGroupBy(0),
+	).SetSize(40, 20)
+
+	press := func(k string) {
+		t.Helper()
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+
+	press("o")
+	if got := m.GetCollapsedGroups(); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("po sbalení: sbalené skupiny = %q, chci [a]", got)
+	}
+	if got := m.GetSelectedRow(); !reflect.DeepEqual(got, []string{"b", "b1"}) {
+		t.Fatalf("po sbalení: vybraný řádek = %q", got)
+	}
+
+	press("o")
+	if got := m.GetCollapsedGroups(); len(got) != 0 {
+		t.Fatalf("druhý stisk skupinu nerozbalil: %q", got)
+	}
+	if got := m.GetSelectedRow(); !reflect.DeepEqual(got, []string{"b", "b1"}) {
+		t.Fatalf("po rozbalení: vybraný řádek = %q", got)
+	}
+
+	press("o")
+	if got := m.GetCollapsedGroups(); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Fatalf("sbalené skupiny = %q, chci [b]", got)
+	}
+
+	m = m.ToggleGroup("c").ToggleGroup("a")
+	if got := m.GetCollapsedGroups(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("sbalené skupiny = %q, chci všechny", got)
+	}
+
+	press("o")
+	if got := m.GetCollapsedGroups(); len(got) != 2 {
+		t.Fatalf("se všemi skupinami sbalenými klávesa nic nerozbalila: %q", got)
+	}
+}
+
+func TestBottomTextTruncation(t *testing.T) {
+	text := "3 vybrané · filtr: deploy"
+
+	for width := 10; width <= 40; width++ {
+		m := NewTableModel(
+			WithHeaders("A"),
+			WithContent([]string{"a"}),
+			WithBottomTextFunc(func(TableModel) string { return text }),
+		).SetSize(width, 6)
+
+		lines := strings.Split(ansi.Strip(m.View()), "\n")
+		bottom := lines[len(lines)-1]
+		if strings.Contains(bottom, "...") {
+			t.Fatalf("šířka %d: text je zkrácený třemi tečkami: %q", width, bottom)
+		}
+		if got := ansi.StringWidth(bottom); got != width {
+			t.Fatalf("šířka %d: spodní okraj má šířku %d: %q", width, got, bottom)
+		}
+	}
+}
+
+func TestCheckboxStyles(t *testing.T) {
+	styles := DefaultStyles()
+	styles.Lines = styles.Lines.Background(lipgloss.Color("#101010"))
+	styles.MarkedLine = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+
+	m := NewTableModel(
+		WithHeaders("A"),
+		WithContent([]string{"1"}, []string{"2"}),
+		WithStyles(styles),
+		WithCheckableRows(true),
+	).ToggleMark(1)
+
+	style := m.lineStyle(1)
+	if got := style.GetBackground(); got != styles.Lines.GetBackground() {
+		t.Fatalf("označený řádek nemá pozadí ze stylu Lines: %v", got)
+	}
+	if got := style.GetForeground(); got != styles.MarkedLine.GetForeground() {
+		t.Fatalf("označený řádek nemá barvu ze stylu MarkedLine: %v", got)
+	}
+	if got := m.lineStyle(0); got.GetForeground() != styles.SelectedLine.GetForeground() {
+		t.Fatalf("vybraný řádek nemá styl SelectedLine: %v", got.GetForeground())
+	}
+}
+
+// checkColSizes() ověří, že sloupečky mají šířku aspoň 1, vyplní celou šířku
+// tabulky a všechny řádky výstupu mají šířku width
+func checkColSizes(t *testing.T, m TableModel, width int) []int {
+	t.Helper()
+
+	sizes := m.computeColSizes()
+	sum := len(sizes) - 1
+	for i, size := range sizes {
+		if size < 1 {
+			t.Fatalf("sloupeček %d má šířku %d: %v", i, size, sizes)
+		}
+		sum += size
+	}
+	if inner := m.innerWidth() - m.prefixWidth(); sum != inner {
+		t.Fatalf("součet šířek %d, chci %d: %v", sum, inner, sizes)
+	}
+
+	for _, line := range strings.Split(ansi.Strip(m.View()), "\n") {
+		if got := ansi.StringWidth(line); got != width {
+			t.Fatalf("řádek má šířku %d, chci %d: %q", got, width, line)
+		}
+	}
+
+	return sizes
+}
+
+func TestColSizesTooManyColumns(t *testing.T) {
+	for _, fit := range []bool{false, true} {
+		for cols := 5; cols <= 14; cols++ {
+			headers := make([]string, cols)
+			row := make([]string, cols)
+			for i := range headers {
+				headers[i] = "Sloupec " + strings.Repeat("x", i)
+				row[i] = "hodnota"
+			}
+
+			m := NewTableModel(WithHeaders(headers...), WithContent(row), WithFitColumns(fit)).SetSize(30, 6)
+			checkColSizes(t, m, 30)
+		}
+	}
+}
+
+func TestColSizesSingleWideColumn(t *testing.T) {
+	wide := strings.Repeat("x", 500)
+
+	for _, fit := range []bool{false, true} {
+		m := NewTableModel(
+			WithHeaders("A", "B", "C"),
+			WithContent([]string{"a", wide, "c"}),
+			WithFitColumns(fit),
+		).SetSize(40, 6)
+
+		sizes := checkColSizes(t, m, 40)
+		if fit && (sizes[0] != 1 || sizes[2] != 1) {
+			t.Fatalf("úzké sloupečky se roztáhly: %v", sizes)
+		}
+
+		m = NewTableModel(
+			WithHeaders("A", "B", "C"),
+			WithContent([]string{"a", wide, "c"}),
+			WithColMaxSizes(0, 10),
+			WithFitColumns(fit),
+		).SetSize(40, 6)
+		if sizes := checkColSizes(t, m, 40); sizes[1] > 10 {
+			t.Fatalf("široký sloupeček překročil maximální šířku: %v", sizes)
+		}
+	}
+}
+
+func TestDistributeWidth(t *testing.T) {
+	tests := []struct {
+		total  int
+		weight []float64
+		lo, hi []int
+		want   []int
+	}{
+		{10, []float64{1, 1, 1}, []int{1, 1, 1}, []int{10, 10, 10}, []int{4, 3, 3}},
+		{2, []float64{1, 1, 1, 1}, []int{1, 1, 1, 1}, []int{2, 2, 2, 2}, []int{1, 1, 1, 1}},
+		{20, []float64{1, 1}, []int{1, 1}, []int{3, 3}, []int{3, 17}},
+		{9, []float64{1, 1, 1}, []int{1, 8, 1}, []int{9, 9, 9}, []int{1, 7, 1}},
+	}
+
+	for _, tt := range tests {
+		if got := distributeWidth(tt.total, tt.weight, tt.lo, tt.hi); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("distributeWidth(%d, %v, %v, %v) = %v, chci %v", tt.total, tt.weight, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+// numbered() vrátí n řádků s jedním sloupečkem s číslem řádku
+func numbered(n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+
+	return rows
+}
+
+func TestShrinkWhileScrolledToBottom(t *testing.T) {
+	m := NewTableModel(WithHeaders("N"), WithContent(numbered(100)...)).SetSize(20, 10)
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if m.GetSelectedLine() != 99 || m.GetScrolledTop() == 0 {
+		t.Fatalf("tabulka není posunutá na konec: řádek %d, posun %d", m.GetSelectedLine(), m.GetScrolledTop())
+	}
+
+	m = m.SetContent(numbered(3)...)
+	if got := m.GetSelectedLine(); got != 2 {
+		t.Fatalf("vybraný řádek = %d, chci 2", got)
+	}
+	if got := m.GetScrolledTop(); got != 0 {
+		t.Fatalf("posun = %d, chci 0", got)
+	}
+	if first, last := m.GetVisibleRange(); first != 0 || last != 2 {
+		t.Fatalf("GetVisibleRange() = %d, %d, chci 0, 2", first, last)
+	}
+	if got := m.GetSelectedRow(); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Fatalf("vybraný řádek = %q", got)
+	}
+
+	view := ansi.Strip(m.View())
+	if got := len(strings.Split(view, "\n")); got != 10 {
+		t.Fatalf("počet řádků = %d, chci 10:\n%s", got, view)
+	}
+	for _, n := range []string{"0", "1", "2"} {
+		if !strings.Contains(view, "│"+n) {
+			t.Fatalf("chybí řádek %s:\n%s", n, view)
+		}
+	}
+}
+
+func TestPageScroll(t *testing.T) {
+	page := NewTableModel(WithHeaders("N")).SetSize(20, 10).viewportRows()
+
+	tests := []struct {
+		name       string
+		rows       int
+		down, up   int // posun po stránce dolů a zpět nahoru
+		downSelect int // vybraný řádek po stránce dolů
+	}{
+		{"méně než stránka", page - 2, 0, 0, page - 3},
+		{"přesně stránka", page, 0, 0, page - 1},
+		{"stránka a řádek", page + 1, 1, 0, page},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTableModel(WithHeaders("N"), WithContent(numbered(tt.rows)...)).SetSize(20, 10)
+
+			m = m.PageScroll(1, true)
+			if got := m.GetScrolledTop(); got != tt.down {
+				t.Fatalf("posun po stránce dolů = %d, chci %d", got, tt.down)
+			}
+			if got := m.GetSelectedLine(); got != tt.downSelect {
+				t.Fatalf("vybraný řádek po stránce dolů = %d, chci %d", got, tt.downSelect)
+			}
+
+			m = m.PageScroll(5, true)
+			if got := m.GetScrolledTop(); got != tt.down {
+				t.Fatalf("posun za konec = %d, chci %d", got, tt.down)
+			}
+
+			m = m.PageScroll(-1, true)
+			if got := m.GetScrolledTop(); got != tt.up {
+				t.Fatalf("posun po stránce nahoru = %d, chci %d", got, tt.up)
+			}
+			if got := m.GetSelectedLine(); got != 0 {
+				t.Fatalf("vybraný řádek po stránce nahoru = %d, chci 0", got)
+			}
+
+			m = m.PageScroll(1, false)
+			if got := m.GetSelectedLine(); got != 0 {
+				t.Fatalf("PageScroll(1, false) posunul vybraný řádek na %d", got)
+			}
+		})
+	}
+}
+
+// scrollBar() vrátí znaky scrollbaru z pravého okraje vedle řádků obsahu
+// Všechny řádky výstupu musí mít šířku tabulky
+func scrollBar(t *testing.T, m TableModel) string {
+	t.Helper()
+
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	for _, line := range lines {
+		if got := ansi.StringWidth(line); got != m.width {
+			t.Fatalf("řádek má šířku %d, chci %d: %q", got, m.width, line)
+		}
+	}
+	above := m.headerY() - m.posY
+
+	var bar strings.Builder
+	for _, line := range lines[above+1 : above+1+m.viewportRows()] {
+		runes := []rune(line)
+		bar.WriteRune(runes[len(runes)-1])
+	}
+
+	return bar.String()
+}
+
+func TestScrollBar(t *testing.T) {
+	m := NewTableModel(WithHeaders("N")).SetSize(20, 12)
+	page := m.viewportRows()
+
+	for _, total := range []int{page + 1, 2 * page, 1000 * page} {
+		m := NewTableModel(WithHeaders("N"), WithContent(numbered(total)...)).SetSize(20, 12)
+
+		_, want := m.scrollThumb(total, 0, page)
+		if want < 1 || want >= page {
+			t.Fatalf("%d řádků: velikost jezdce = %d", total, want)
+		}
+
+		for _, pos := range []string{"nahoře", "uprostřed", "dole"} {
+			switch pos {
+			case "uprostřed":
+				m = m.SetSelectedLine(total / 2)
+			case "dole":
+				m = m.SetSelectedLine(total - 1)
+			}
+
+			bar := scrollBar(t, m)
+			if len([]rune(bar)) != page {
+				t.Fatalf("%d řádků, %s: scrollbar má %d znaků: %q", total, pos, len([]rune(bar)), bar)
+			}
+			if got := strings.Count(bar, "█"); got != want {
+				t.Fatalf("%d řádků, %s: jezdec má %d znaků, chci %d: %q", total, pos, got, want, bar)
+			}
+			if strings.Trim(bar, "░") != strings.Repeat("█", want) {
+				t.Fatalf("%d řádků, %s: jezdec není souvislý: %q", total, pos, bar)
+			}
+
+			top, bottom := strings.HasPrefix(bar, "█"), strings.HasSuffix(bar, "█")
+			switch {
+			case pos == "nahoře" && (!top || bottom),
+				pos == "dole" && (top || !bottom),
+				pos == "uprostřed" && total > 2*page && (top || bottom):
+				t.Fatalf("%d řádků, %s: jezdec je na špatném místě: %q", total, pos, bar)
+			}
+		}
+	}
+}
+
+func BenchmarkView(b *testing.B) {
+	rows := make([][]string, 100_000)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i), "jméno " + strconv.Itoa(i%977), strconv.Itoa(i * 7 % 1000)}
+	}
+
+	m := NewTableModel(WithHeaders("ID", "Jméno", "Hodnota"), WithContent(rows...)).SetSize(80, 40)
+
+	b.Run("beze změny", func(b *testing.B) {
+		for b.Loop() {
+			_ = m.View()
+		}
+	})
+
+	b.Run("posun", func(b *testing.B) {
+		down := tea.KeyMsg{Type: tea.KeyDown}
+		for b.Loop() {
+			m, _, _ = m.Update(down)
+			_ = m.View()
+		}
+	})
+}
+
+func TestGetVisibleRange(t *testing.T) {
+	m := NewTableModel(WithHeaders("N"), WithContent(numbered(50)...)).SetSize(20, 10)
+	page := m.viewportRows()
+
+	tests := []struct {
+		name  string
+		top   int
+		first int
+	}{
+		{"nahoře", 0, 0},
+		{"posunuto o řádek", 1, 1},
+		{"uprostřed", 20, 20},
+		{"poslední stránka", 50 - page, 50 - page},
+		{"za koncem", 45, 50 - page},
+		{"daleko za koncem", 1000, 50 - page},
+		{"záporný posun", -5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := m
+			m.scrolledTop = tt.top
+
+			first, last := m.GetVisibleRange()
+			if first != tt.first || last != tt.first+page-1 {
+				t.Fatalf("GetVisibleRange() = %d, %d, chci %d, %d", first, last, tt.first, tt.first+page-1)
+			}
+			if got := m.GetScrolledTop(); got != first {
+				t.Fatalf("GetScrolledTop() = %d, chci %d", got, first)
+			}
+		})
+	}
+
+	m = m.SetContent(numbered(3)...)
+	if first, last := m.GetVisibleRange(); first != 0 || last != 2 {
+		t.Fatalf("méně řádků než stránka: GetVisibleRange() = %d, %d, chci 0, 2", first, last)
+	}
+
+	m = m.SetContent()
+	if first, last := m.GetVisibleRange(); first != -1 || last != -1 {
+		t.Fatalf("prázdná tabulka: GetVisibleRange() = %d, %d, chci -1, -1", first, last)
+	}
+}
+
+func TestColFlexOddWidths(t *testing.T) {
+	weights := []float64{3, 1, 6}
+	options := []func(*TableModel){
+		WithHeaders("A", "B", "C"),
+		WithContent([]string{"a", "b", "c"}),
+		WithColFlex(weights...),
+	}
+
+	// stejná tabulka opakovaně zvětšovaná a zmenšovaná
+	resized := NewTableModel(options...)
+
+	for _, width := range []int{21, 120, 33, 99, 35, 77, 41, 121, 23, 65, 37, 101, 39} {
+		resized = resized.SetSize(width, 6)
+		m := NewTableModel(options...).SetSize(width, 6)
+
+		sizes := checkColSizes(t, m, width)
+		if got := checkColSizes(t, resized, width); !reflect.DeepEqual(got, sizes) {
+			t.Fatalf("šířka %d: po změnách velikosti %v, nová tabulka %v", width, got, sizes)
+		}
+
+		available := m.innerWidth() - m.prefixWidth() - (len(sizes) - 1)
+		for i, size := range sizes {
+			exact := float64(available) * weights[i] / 10
+			if diff := float64(size) - exact; diff <= -1 || diff >= 1 {
+				t.Fatalf("šířka %d: sloupeček %d má %d, přesně %.1f: %v", width, i, size, exact, sizes)
+			}
+		}
+	}
+}
+
+func TestSortToggleKeepsRow(t *testing.T) {
+	rows := make([][]string, 40)
+	for i := range rows {
+		rows[i] = []string{string(rune('a' + i%3)), strconv.Itoa(i)}
+	}
+
+	m := NewTableModel(WithHeaders("Klíč", "N"), WithContent(rows...)).SetSize(20, 8)
+
+	for _, index := range []int{0, 17, 39} {
+		m := m.SetSelectedLine(index)
+		want := m.GetSelectedRow()
+
+		for n, dir := range []SortOrder{
+			SortAscendig, SortDescending, SortAscendig, SortDescending,
+			SortUnsorted, SortDescending, SortAscendig, SortAscendig, SortUnsorted,
+		} {
+			m = m.Sort(0, dir)
+
+			if got := m.GetSelectedRow(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("řádek %d, řazení %d (%v): vybraný řádek = %q, chci %q", index, n, dir, got, want)
+			}
+			if first, last := m.GetVisibleRange(); m.GetSelectedLine() < first || m.GetSelectedLine() > last {
+				t.Fatalf("řádek %d, řazení %d (%v): vybraný řádek %d není vidět (%d–%d)",
+					index, n, dir, m.GetSelectedLine(), first, last)
+			}
+		}
+	}
+}
+
+func TestFilterShortRows(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("A", "B"),
+		WithContent([]string{"a", "zz"}, []string{"b", "c"}),
+	).SetSize(30, 8)
+
+	m = m.InsertRow(0, []string{"x"}).UpdateRow(2, []string{"z"})
+	m = m.SetFilter("z")
+
+	want := [][]string{{"a", "zz"}, {"z"}}
+	if got := m.sortedContent; !reflect.DeepEqual(got, want) {
+		t.Fatalf("vyfiltrované řádky = %q, chci %q", got, want)
+	}
+	_ = m.View()
+}
+
+func TestFuzzyFilterShortRows(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("A", "B"),
+		WithContent([]string{"a", "zz"}, []string{"b", "c"}),
+		WithFuzzyFilter(true),
+	).SetSize(30, 8)
+
+	m = m.InsertRow(0, []string{"x"}).SetFilter("z")
+	if got := m.sortedContent; !reflect.DeepEqual(got, [][]string{{"a", "zz"}}) {
+		t.Fatalf("vyfiltrované řádky = %q", got)
+	}
+	_ = m.View()
+}
+
+func TestFooterKeepsSelectionVisible(t *testing.T) {
+	visible := func(t *testing.T, m TableModel) {
+		t.Helper()
+		first, last := m.GetVisibleRange()
+		if line := m.GetSelectedLine(); line < first || line > last {
+			t.Fatalf("vybraný řádek %d není vidět (%d–%d)", line, first, last)
+		}
+		if !strings.Contains(ansi.Strip(m.View()), "│"+m.GetSelectedRow()[0]) {
+			t.Fatalf("vybraný řádek chybí ve výstupu:\n%s", ansi.Strip(m.View()))
+		}
+	}
+
+	m := NewTableModel(WithHeaders("N"), WithContent(numbered(30)...)).SetSize(20, 10)
+	m = m.SetSelectedLine(m.viewportRows() - 1)
+
+	m = m.SetFooter("total")
+	visible(t, m)
+
+	m = m.SetFooter()
+	visible(t, m)
+
+	m = m.SetFooterFunc(func([][]string) []string { return []string{"součet"} })
+	visible(t, m)
+}
+
+func TestTitleTruncation(t *testing.T) {
+	for width := 8; width <= 30; width++ {
+		m := NewTableModel(
+			WithTitle("Dlouhý název tabulky"),
+			WithHeaders("A"),
+			WithContent([]string{"a"}),
+		).SetSize(width, 6)
+
+		top := strings.Split(ansi.Strip(m.View()), "\n")[0]
+		if strings.Contains(top, "...") {
+			t.Fatalf("šířka %d: titulek je zkrácený třemi tečkami: %q", width, top)
+		}
+		if got := ansi.StringWidth(top); got != width {
+			t.Fatalf("šířka %d: horní okraj má šířku %d: %q", width, got, top)
+		}
+	}
+}
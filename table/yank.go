@@ -0,0 +1,87 @@
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// RowYankedMsg je zpráva, kterou vrací tea.Cmd z Yank() a YankMarked() s WithOSC52(false)
+// nebo bez WithOSC52Writer()
+// Rows jsou zkopírované řádky se sloupečky v pořadí zobrazení a bez ANSI stylů,
+// Text jsou tytéž řádky jako hodnoty oddělené tabulátorem (TSV)
+type RowYankedMsg struct {
+	Rows [][]string
+	Text string
+}
+
+// Yank() vrátí tea.Cmd, který zkopíruje vybraný řádek do schránky jako hodnoty
+// oddělené tabulátorem (buňky s tabulátorem, uvozovkami nebo koncem řádku jsou
+// v uvozovkách), viz WithOSC52()
+// S WithColumnCursor(true) zkopíruje jen vybranou buňku
+// Pokud není vybraný žádný řádek, vrátí nil
+func (m TableModel) Yank() tea.Cmd {
+	i := m.selectedContentIndex()
+	if i < 0 {
+		return nil
+	}
+
+	order := m.columnOrder()
+	if col := m.selectedColumn(); col >= 0 {
+		order = []int{col}
+	}
+
+	return m.yank([][]string{m.exportRow(m.sortedContent[m.selectedLine], order)})
+}
+
+// YankMarked() vrátí tea.Cmd, který zkopíruje do schránky označené řádky, pokud
+// není označený žádný zobrazený řádek, zkopíruje všechny zobrazené řádky, viz Yank()
+// Pokud je tabulka prázdná, vrátí nil
+func (m TableModel) YankMarked() tea.Cmd {
+	rows := m.exportRows(true)
+	if len(rows) == 0 {
+		rows = m.exportRows(false)
+	}
+
+	return m.yank(rows)
+}
+
+// yank() vrátí tea.Cmd, který zkopíruje rows do schránky pomocí OSC 52 (viz
+// WithOSC52Writer()), nebo s WithOSC52(false) či bez writeru pošle RowYankedMsg
+func (m TableModel) yank(rows [][]string) tea.Cmd {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	cw.Comma = '\t'
+	if err := cw.WriteAll(rows); err != nil {
+		return nil
+	}
+
+	msg := RowYankedMsg{
+		Rows: rows,
+		Text: strings.TrimSuffix(buf.String(), "\n"),
+	}
+
+	// do os.Stdout se z tea.Cmd nezapisuje, běží souběžně s vykreslováním
+	// bubbletea a sekvence by se mohla dostat doprostřed snímku
+	if !m.osc52 || m.osc52Writer == nil {
+		return func() tea.Msg {
+			return msg
+		}
+	}
+
+	w := m.osc52Writer
+
+	return func() tea.Msg {
+		_, _ = io.WriteString(w, ansi.SetSystemClipboard(msg.Text))
+
+		return nil
+	}
+}
@@ -0,0 +1,55 @@
+package table
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestYankWriter(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewTableModel(
+		WithHeaders("A", "B"),
+		WithContent([]string{lipgloss.NewStyle().Bold(true).Render("x"), "a\tb"}),
+		WithOSC52Writer(&buf),
+	)
+
+	if msg := m.Yank()(); msg != nil {
+		t.Fatalf("tea.Cmd vrátil %v, chci nil", msg)
+	}
+
+	if want := ansi.SetSystemClipboard("x\t\"a\tb\""); buf.String() != want {
+		t.Fatalf("zapsáno %q, chci %q", buf.String(), want)
+	}
+}
+
+func TestYankMarkedWithoutOSC52(t *testing.T) {
+	m := NewTableModel(
+		WithHeaders("A"),
+		WithContent([]string{"1"}, []string{"2"}, []string{"3"}),
+		WithOSC52(false),
+	).ToggleMark(1)
+
+	msg, ok := m.YankMarked()().(RowYankedMsg)
+	if !ok {
+		t.Fatal("tea.Cmd nevrátil RowYankedMsg")
+	}
+	if want := [][]string{{"2"}}; !reflect.DeepEqual(msg.Rows, want) || msg.Text != "2" {
+		t.Fatalf("zkopírováno %q (%q), chci %q", msg.Rows, msg.Text, want)
+	}
+}
+
+func TestYankWithoutWriter(t *testing.T) {
+	m := NewTableModel(WithHeaders("A", "B"), WithContent([]string{"x", "y"}))
+
+	msg, ok := m.Yank()().(RowYankedMsg)
+	if !ok {
+		t.Fatal("bez WithOSC52Writer() tea.Cmd nevrátil RowYankedMsg")
+	}
+	if msg.Text != "x\ty" {
+		t.Fatalf("zkopírováno %q, chci %q", msg.Text, "x\ty")
+	}
+}
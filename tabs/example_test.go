@@ -0,0 +1,36 @@
+package tabs_test
+
+import (
+	"fmt"
+
+	"github.com/tomaspantlik/crapmodels/tabs"
+	"github.com/tomaspantlik/crapmodels/window"
+)
+
+// Záložky vlevo připojené k oknu s obsahem vybrané záložky
+func ExampleWithAttached() {
+	t := tabs.NewTabsModel(
+		tabs.WithTabs("Jedna", "Dvě", "Tři"),
+		tabs.WithAttached(tabs.SideRight),
+	).SetSize(10, 9)
+
+	name, _ := t.GetSelectedTabName()
+
+	// okno je o jeden sloupec širší, jeho levý okraj nahradí okraj záložek
+	w := window.NewWindowModel(
+		window.WithTitle("Obsah"),
+		window.WithContent("Záložka "+name),
+	).SetSize(21, 9)
+
+	fmt.Println(t.ViewAttached(w.View()))
+	// Output:
+	// ╭───────────────[Obsah]──────╮
+	// │ Jedna >                    │
+	// ├────────╮                   │
+	// │  Dvě   │                   │
+	// ├────────┤   Záložka Jedna   │
+	// │  Tři   │                   │
+	// ╰────────┤                   │
+	//          │                   │
+	//          ╰───────────────────╯
+}
@@ -2,33 +2,91 @@
 package tabs
 
 import (
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 var (
 	// DefaultKeys je výchozí mapování klávesových zkratek
 	DefaultKeys = Keys{
-		Next1: tea.KeyTab.String(),
-		Next2: tea.KeyCtrlN.String(),
-		Prev1: tea.KeyShiftTab.String(),
-		Prev2: tea.KeyCtrlP.String(),
+		Next1:  tea.KeyTab.String(),
+		Next2:  tea.KeyCtrlN.String(),
+		Prev1:  tea.KeyShiftTab.String(),
+		Prev2:  tea.KeyCtrlP.String(),
+		Close1: tea.KeyCtrlW.String(),
+
+		MoveUp1:   tea.KeyCtrlUp.String(),
+		MoveDown1: tea.KeyCtrlDown.String(),
 	}
 )
 
+// TabChangedMsg je zpráva, kterou vrací tea.Cmd z Update(), pokud se zpracováním
+// klávesy změnila vybraná záložka
+// Name je text nově vybrané záložky
+type TabChangedMsg struct {
+	OldIndex int
+	NewIndex int
+	Name     string
+}
+
+// TabCloseRequestedMsg je zpráva, kterou vrací tea.Cmd z Update() po stisku
+// klávesy pro zavření vybrané záložky
+// Záložka se neodebere, o zavření rozhoduje aplikace voláním ConfirmClose()
+type TabCloseRequestedMsg struct {
+	Index int
+	Name  string
+}
+
+// TabMovedMsg je zpráva, kterou vrací tea.Cmd z Update(), pokud se klávesou
+// přesunula vybraná záložka z indexu From na index To
+type TabMovedMsg struct {
+	From int
+	To   int
+}
+
+// Side je strana záložek, ke které je připojený obsah, viz WithAttached()
+type Side int
+
+const (
+	// SideNone znamená, že záložky nejsou k obsahu připojené
+	SideNone Side = iota
+	// SideRight připojí obsah napravo od záložek
+	SideRight
+	// SideLeft připojí obsah nalevo od záložek
+	SideLeft
+)
+
+// TabStyleFunc je funkce, která vrací styl záložky
+// index je index záložky, name její text a selected true pro vybranou záložku
+type TabStyleFunc func(index int, name string, selected bool) lipgloss.Style
+
 // Keys je typ pro definování klávesových zkratek
 // Vychází z bubbletea.KeyMsg.String()
 // Každá akce může mít více klávesových zkratek (Next1, Next2, ...)
 // Pokud je nastaveno na "", tak se ignoruje
 type Keys struct {
-	Next1 string
-	Next2 string
-	Next3 string
-	Prev1 string
-	Prev2 string
-	Prev3 string
+	Next1  string
+	Next2  string
+	Next3  string
+	Prev1  string
+	Prev2  string
+	Prev3  string
+	Close1 string
+	Close2 string
+	Close3 string
+
+	MoveUp1   string
+	MoveUp2   string
+	MoveUp3   string
+	MoveDown1 string
+	MoveDown2 string
+	MoveDown3 string
 }
 
 // TextModel je model pro použití v bubbletea aplikaci
@@ -41,10 +99,35 @@ type TabsModel struct {
 	borderType       lipgloss.Border
 	tabStyle         lipgloss.Style
 	selectedTabStyle lipgloss.Style
+	disabledTabStyle lipgloss.Style
 	borderStyle      lipgloss.Style
+	prefixStyle      lipgloss.Style
+	descriptionStyle lipgloss.Style
+	separatorStyle   lipgloss.Style
+	tabStyleFunc     TabStyleFunc
 
 	tabs        []string
 	selectedTab int
+	scrollTop   int
+	disabled    map[int]bool
+	pinned      map[int]bool
+	prefixes    map[int]string
+	descs       map[int]string
+	separators  map[int][]string
+	contents    map[int]tea.Model
+	jumpPrefix  string
+
+	attached      Side
+	keepIndexes   bool
+	autoWidth     bool
+	emitOnSet     bool
+	pendingChange *TabChangedMsg
+	// vybraná záložka byla odebrána, pendingChange je změna i při stejném indexu
+	pendingRemoved bool
+
+	position         bool
+	positionOnTop    bool
+	positionCountAll bool
 }
 
 // NewTabsModel() je funkce pro vytvoření nového TabsModelu
@@ -61,14 +144,23 @@ func NewTabsModel(options ...func(*TabsModel)) TabsModel {
 			Bold(true).
 			Background(lipgloss.Color("#FFFFFF")).
 			Foreground(lipgloss.Color("#000000")),
+		disabledTabStyle: lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Faint(true),
 		borderStyle: lipgloss.NewStyle(),
+		prefixStyle: lipgloss.NewStyle(),
+		descriptionStyle: lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Faint(true),
+		separatorStyle: lipgloss.NewStyle().
+			Bold(true),
 	}
 
 	for _, opt := range options {
 		opt(&t)
 	}
 
-	return t
+	return t.fitWidth()
 }
 
 // WithKeys() definuje vlastní klávesové zkratky modelu
@@ -88,6 +180,160 @@ func WithTabs(tabs ...string) func(*TabsModel) {
 	}
 }
 
+// WithJumpPrefix() nastaví předponu kláves pro skok na záložku podle čísla, např.
+// "alt+" pro alt+1 až alt+9
+// Klávesa 1 vybere první záložku, 9 devátou, klávesa se přebere jen tehdy, pokud
+// záložka s daným číslem existuje, jinak ji Update() pošle dál
+// Pokud není použito, skáče se klávesami 1 až 9 bez předpony
+func WithJumpPrefix(prefix string) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.jumpPrefix = prefix
+	}
+}
+
+// WithDisabledTabs() nastaví neaktivní záložky, viz SetTabDisabled()
+func WithDisabledTabs(indexes ...int) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.disabled = make(map[int]bool, len(indexes))
+		for _, i := range indexes {
+			tm.disabled[i] = true
+		}
+	}
+}
+
+// WithPinnedTabs() nastaví záložky, které nelze zavřít, viz SetTabCloseable()
+func WithPinnedTabs(indexes ...int) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.pinned = make(map[int]bool, len(indexes))
+		for _, i := range indexes {
+			tm.pinned[i] = true
+		}
+	}
+}
+
+// WithTabPrefixes() nastaví předpony záložek podle indexu, viz SetTabPrefix()
+func WithTabPrefixes(prefixes map[int]string) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.prefixes = maps.Clone(prefixes)
+	}
+}
+
+// WithTabPrefixColor() nastaví barvu popředí předpon záložek
+// Pozadí a ostatní vlastnosti předpona přebírá ze stylu záložky
+func WithTabPrefixColor(fg lipgloss.Color) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.prefixStyle = tm.prefixStyle.Foreground(fg)
+	}
+}
+
+// WithDescriptionColors() nastaví barvu pozadí a popředí pro popisy záložek,
+// viz SetTabDescription()
+func WithDescriptionColors(bg, fg lipgloss.Color) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.descriptionStyle = tm.descriptionStyle.Background(bg).Foreground(fg)
+	}
+}
+
+// WithSeparatorColors() nastaví barvu pozadí a popředí pro oddělovače, viz
+// AddSeparator()
+func WithSeparatorColors(bg, fg lipgloss.Color) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.separatorStyle = tm.separatorStyle.Background(bg).Foreground(fg)
+	}
+}
+
+// WithDisabledTabColors() nastaví barvu pozadí a popředí pro neaktivní taby
+func WithDisabledTabColors(bg, fg lipgloss.Color) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.disabledTabStyle = tm.disabledTabStyle.Background(bg).Foreground(fg)
+	}
+}
+
+// WithTabStyleFunc() nastaví funkci pro styl jednotlivých záložek
+// Vrácený styl se použije místo stylů nevybraných, vybraných i neaktivních tabů,
+// šířku a zarovnání si model nastaví sám, značka ">" vybrané záložky má stejný styl
+func WithTabStyleFunc(f TabStyleFunc) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.tabStyleFunc = f
+	}
+}
+
+// WithAttached() připojí záložky k obsahu (např. k oknu window.WindowModel) na
+// straně side
+// Okraj záložek na této straně se vykreslí se spojkami (┬, ┤, ┴ podle typu
+// okraje), u vybrané záložky je okraj otevřený a pod záložkami okraj pokračuje
+// až do výšky modelu, takže se okraj záložek spojí s okrajem obsahu
+// Pro spojení s obsahem použít ViewAttached(), např.:
+//
+//	m.tabs = tabs.NewTabsModel(tabs.WithTabs("Jedna", "Dvě"), tabs.WithAttached(tabs.SideRight)).
+//		SetSize(12, 20)
+//	m.win = window.NewWindowModel(window.WithTitle("Obsah")).SetSize(41, 20)
+//
+//	// ve View()
+//	return m.tabs.ViewAttached(m.win.View())
+//
+// Okno je o jeden sloupec širší, protože jeho levý okraj nahradí okraj záložek
+// Pokud není použito, záložky mají samostatný okraj (SideNone)
+func WithAttached(side Side) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.attached = side
+	}
+}
+
+// WithKeepIndexes() nastaví, že SetTabs() zachová vybranou záložku i údaje
+// záložek (neaktivní záložky, předpony, popisy, ...) podle indexu, ne podle textu
+// záložky
+func WithKeepIndexes(keep bool) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.keepIndexes = keep
+	}
+}
+
+// WithPositionIndicator() nastaví zobrazení pozice vybrané záložky, např. "2/7",
+// vpravo ve spodním okraji záložek ve stylu okraje
+// Počítají se jen aktivní záložky (a vybraná záložka), viz WithPositionCountAll()
+// Pokud se ukazatel do šířky nevejde, zobrazí se jen pozice, případně nic
+func WithPositionIndicator(show bool) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.position = show
+	}
+}
+
+// WithPositionIndicatorOnTop() nastaví zobrazení ukazatele pozice v horním okraji
+// místo spodního, viz WithPositionIndicator()
+func WithPositionIndicatorOnTop(top bool) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.positionOnTop = top
+	}
+}
+
+// WithPositionCountAll() nastaví, že ukazatel pozice počítá i neaktivní záložky,
+// viz WithPositionIndicator()
+func WithPositionCountAll(all bool) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.positionCountAll = all
+	}
+}
+
+// WithAutoWidth() nastaví, že si model šířku počítá sám podle nejdelšího textu
+// záložky (viz GetPreferredWidth()) a přepočítává ji při každé změně záložek,
+// SetSize() pak nastavuje jen výšku
+func WithAutoWidth(auto bool) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.autoWidth = auto
+	}
+}
+
+// WithEmitOnSet() nastaví, že i SetSelectedTab() a SelectTabByName() způsobí
+// poslání TabChangedMsg
+// Zpráva se vrací jako tea.Cmd z nejbližšího volání Update()
+// Pokud není použito, posílá se TabChangedMsg jen při ovládání klávesami
+func WithEmitOnSet(emit bool) func(*TabsModel) {
+	return func(tm *TabsModel) {
+		tm.emitOnSet = emit
+	}
+}
+
 // WithBorderType() nastaví styl okraje záložek
 // Pokud není použito, je nastaven výchozí styl lipgloss.RoundedBorder()
 func WithBorderType(borderStyle lipgloss.Border) func(*TabsModel) {
@@ -128,6 +374,16 @@ func WithBorderColors(fg, bg lipgloss.Color) func(*TabsModel) {
 //
 // Pokud je předána klávesová zkratka, která je v modelu zaregistrovaná pro ovládání,
 // model si ji přebere a nepošle je dál. Ostatní tea.KeyMsg i tea.Msg posílá zpět
+// Pokud se změní vybraná záložka, vrací tea.Cmd s TabChangedMsg
+// Klávesy 1 až 9 (viz WithJumpPrefix()) vyberou záložku podle čísla, neaktivní
+// záložky se při přepínání přeskakují a číslem je nelze vybrat
+// Zprávy, které model nepřebere (kromě kláves pro přepínání záložek), předá
+// modelu obsahu vybrané záložky, pokud je zaregistrovaný (viz RegisterContent()),
+// a vrací je i zpět, tea.WindowSizeMsg předá modelům všech záložek
+// Klávesa pro zavření vrací tea.Cmd s TabCloseRequestedMsg pro vybranou záložku,
+// pokud ji lze zavřít, záložku ale neodebere, viz ConfirmClose()
+// Klávesy pro přesun posunou vybranou záložku o jednu pozici a vrací tea.Cmd
+// s TabMovedMsg, viz MoveTab()
 //
 // Pak použít něco jako toto v hlavním Update() pro přepínání obsahu pomocí tabů:
 //
@@ -143,37 +399,327 @@ func WithBorderColors(fg, bg lipgloss.Color) func(*TabsModel) {
 //		m.text2, cmd, msg = m.text2.Update(msg)
 //		cmds = append(cmds, cmd)
 //	}
+//
+// Nebo místo přepínání zaregistrovat obsah záložek:
+//
+//	m.tabs = m.tabs.RegisterContent(0, text1).RegisterContent(1, text2)
 func (m TabsModel) Update(msg tea.Msg) (TabsModel, tea.Cmd, tea.Msg) {
+	var cmds []tea.Cmd
+
+	if m.pendingChange != nil {
+		if m.pendingChange.OldIndex != m.pendingChange.NewIndex || m.pendingRemoved {
+			cmds = append(cmds, tabChanged(*m.pendingChange))
+		}
+		m.pendingChange = nil
+		m.pendingRemoved = false
+	}
+
+	oldTab := m.selectedTab
+	ret := msg
+	forward := true
+
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
 		if msg.Height < m.height {
 			m.height = msg.Height
 		}
-		if msg.Width < m.width {
+		if msg.Width < m.width && !m.autoWidth {
 			m.width = msg.Width
 		}
 
 	case tea.KeyMsg:
+		if len(m.tabs) == 0 {
+			break
+		}
+
+		if i, ok := m.jumpTarget(msg.String()); ok {
+			if !m.disabled[i] {
+				m.selectedTab = i
+			}
+			ret = nil
+			break
+		}
+
 		switch msg.String() {
 		case m.keys.Next1, m.keys.Next2, m.keys.Next3:
-			if m.selectedTab < len(m.tabs)-1 {
-				m.selectedTab++
-			} else {
-				m.selectedTab = 0
-			}
+			m.selectedTab = m.nextTab(1)
+			forward = false
 
 		case m.keys.Prev1, m.keys.Prev2, m.keys.Prev3:
-			if m.selectedTab > 0 {
-				m.selectedTab--
-			} else {
-				m.selectedTab = len(m.tabs) - 1
+			m.selectedTab = m.nextTab(-1)
+			forward = false
+
+		case m.keys.MoveUp1, m.keys.MoveUp2, m.keys.MoveUp3,
+			m.keys.MoveDown1, m.keys.MoveDown2, m.keys.MoveDown3:
+			ret = nil
+
+			from, to := m.selectedTab, m.selectedTab+1
+			switch msg.String() {
+			case m.keys.MoveUp1, m.keys.MoveUp2, m.keys.MoveUp3:
+				to = m.selectedTab - 1
+			}
+			if to < 0 || to >= len(m.tabs) {
+				break
+			}
+
+			m = m.MoveTab(from, to)
+			oldTab = m.selectedTab
+			cmds = append(cmds, tabMoved(TabMovedMsg{From: from, To: to}))
+
+		case m.keys.Close1, m.keys.Close2, m.keys.Close3:
+			ret = nil
+			if m.IsTabCloseable(m.selectedTab) {
+				cmds = append(cmds, closeRequested(TabCloseRequestedMsg{
+					Index: m.selectedTab,
+					Name:  m.tabs[m.selectedTab],
+				}))
+			}
+		}
+
+	}
+
+	if m.selectedTab != oldTab {
+		cmds = append(cmds, tabChanged(m.tabChangedMsg(oldTab)))
+	}
+
+	// změnu velikosti dostanou i modely neaktivních záložek, aby po přepnutí
+	// nezobrazily starý layout
+	targets := []int{m.selectedTab}
+	if _, ok := ret.(tea.WindowSizeMsg); ok {
+		targets = slices.Sorted(maps.Keys(m.contents))
+	}
+
+	if forward && ret != nil {
+		for _, i := range targets {
+			content, ok := m.contents[i]
+			if !ok {
+				continue
+			}
+
+			var cmd tea.Cmd
+			content, cmd = content.Update(ret)
+			m.contents = maps.Clone(m.contents)
+			m.contents[i] = content
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	return m.scrollToSelected(), tea.Batch(cmds...), ret
+}
+
+// row je řádek seznamu záložek, záložka s indexem tab, nebo oddělovač s textem
+// label (tab je pak -1), viz AddSeparator()
+type row struct {
+	tab   int
+	label string
+}
+
+// rows() vrátí seznam záložek i s oddělovači v pořadí, ve kterém se zobrazují
+func (m TabsModel) rows() []row {
+	rows := make([]row, 0, len(m.tabs))
+	for i := range len(m.tabs) + 1 {
+		for _, label := range m.separators[i] {
+			rows = append(rows, row{tab: -1, label: label})
+		}
+		if i < len(m.tabs) {
+			rows = append(rows, row{tab: i})
+		}
+	}
+
+	return rows
+}
+
+// tabRows() vrátí počet řádků textu jedné záložky, 2 pokud se zobrazují popisy
+// Popisy se zobrazí jen tehdy, pokud se s nimi do výšky vejdou všechny záložky
+func (m TabsModel) tabRows() int {
+	if len(m.descs) == 0 {
+		return 1
+	}
+
+	separators := 0
+	for i, labels := range m.separators {
+		if i <= len(m.tabs) {
+			separators += len(labels)
+		}
+	}
+	if len(m.tabs)*3+separators*2+1 <= m.height {
+		return 2
+	}
+
+	return 1
+}
+
+// rowHeight() vrátí počet řádků, které zabírá r i s okrajem pod ním, tabRows je
+// výsledek tabRows()
+func rowHeight(r row, tabRows int) int {
+	if r.tab < 0 {
+		return 2
+	}
+
+	return tabRows + 1
+}
+
+// visibleEnd() vrátí index za posledním řádkem z rows, který se vejde do výšky,
+// pokud je první zobrazený řádek top
+// Zobrazí se vždy alespoň jeden řádek
+func (m TabsModel) visibleEnd(rows []row, top int) int {
+	tabRows := m.tabRows()
+
+	used, end := 1, top
+	for ; end < len(rows); end++ {
+		used += rowHeight(rows[end], tabRows)
+		if end > top && used > m.height {
+			break
+		}
+	}
+
+	return end
+}
+
+// scrollToSelected() posune seznam záložek tak, aby byla vybraná záložka vidět
+// i s oddělovači těsně nad ní
+func (m TabsModel) scrollToSelected() TabsModel {
+	rows := m.rows()
+
+	selected := slices.IndexFunc(rows, func(r row) bool { return r.tab == m.selectedTab })
+	selected = max(selected, 0)
+
+	first := selected
+	for first > 0 && rows[first-1].tab < 0 {
+		first--
+	}
+
+	m.scrollTop = max(min(m.scrollTop, first, len(rows)-1), 0)
+	for m.scrollTop < selected && m.visibleEnd(rows, m.scrollTop) <= selected {
+		m.scrollTop++
+	}
+	for m.scrollTop > 0 && m.visibleEnd(rows, m.scrollTop-1) == len(rows) {
+		m.scrollTop--
+	}
+
+	return m
+}
+
+// borderLine() vykreslí vodorovný okraj ze znaků line mezi rohy left a right,
+// uprostřed se značkou mark (např. "▲"), pokud není ""
+// Vpravo zobrazí první z labels, který se vejde vedle značky, pokud se nevejde
+// žádný, zobrazí se okraj bez textu
+func (m TabsModel) borderLine(left, line, right, mark string, labels ...string) string {
+	cells := make([]string, max(m.width-2, 0))
+	for i := range cells {
+		cells[i] = line
+	}
+
+	// text nesmí začínat hned u rohu ani u značky
+	from := 1
+	if mark != "" && len(cells) > 0 {
+		pos := len(cells) / 2
+		cells[pos] = mark
+		from = pos + 2
+	}
+
+	for _, label := range labels {
+		start := len(cells) - 1 - len([]rune(label))
+		if start < from {
+			continue
+		}
+		for i, r := range []rune(label) {
+			cells[start+i] = string(r)
+		}
+		break
+	}
+
+	return m.borderStyle.Render(left + strings.Join(cells, "") + right)
+}
+
+// positionLabels() vrátí text ukazatele pozice vybrané záložky, např. "2/7",
+// a jeho zkrácené varianty, viz WithPositionIndicator()
+// Bez ukazatele pozice nebo bez záložek vrátí nil
+func (m TabsModel) positionLabels() []string {
+	if !m.position || len(m.tabs) == 0 {
+		return nil
+	}
+
+	pos, total := m.selectedTab+1, len(m.tabs)
+	if !m.positionCountAll {
+		pos, total = 0, 0
+		for i := range m.tabs {
+			if m.disabled[i] && i != m.selectedTab {
+				continue
+			}
+			total++
+			if i <= m.selectedTab {
+				pos++
 			}
 		}
+	}
+
+	return []string{strconv.Itoa(pos) + "/" + strconv.Itoa(total), strconv.Itoa(pos)}
+}
 
+// jumpTarget() vrátí index záložky pro klávesu key s číslem, viz WithJumpPrefix()
+// Pokud key není klávesa pro skok nebo záložka s daným číslem neexistuje, vrátí
+// false
+func (m TabsModel) jumpTarget(key string) (int, bool) {
+	digit, ok := strings.CutPrefix(key, m.jumpPrefix)
+	if !ok || len(digit) != 1 {
+		return 0, false
 	}
 
-	return m, nil, msg
+	n, err := strconv.Atoi(digit)
+	if err != nil || n < 1 || n > len(m.tabs) {
+		return 0, false
+	}
+
+	return n - 1, true
+}
+
+// nextTab() vrátí index další aktivní záložky ve směru step (1 nebo -1), za
+// poslední záložkou pokračuje první a naopak
+// Pokud jsou všechny ostatní záložky neaktivní, vrátí vybranou záložku
+func (m TabsModel) nextTab(step int) int {
+	n := len(m.tabs)
+
+	for i, tab := 1, m.selectedTab; i <= n; i++ {
+		tab = ((tab+step)%n + n) % n
+		if !m.disabled[tab] {
+			return tab
+		}
+	}
+
+	return m.selectedTab
+}
+
+// tabChangedMsg() vrátí TabChangedMsg pro změnu výběru z oldTab na vybranou záložku
+func (m TabsModel) tabChangedMsg(oldTab int) TabChangedMsg {
+	msg := TabChangedMsg{OldIndex: oldTab, NewIndex: m.selectedTab}
+	if m.selectedTab >= 0 && m.selectedTab < len(m.tabs) {
+		msg.Name = m.tabs[m.selectedTab]
+	}
+
+	return msg
+}
+
+// tabChanged() vrátí tea.Cmd, který pošle msg
+func tabChanged(msg TabChangedMsg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// tabMoved() vrátí tea.Cmd, který pošle msg
+func tabMoved(msg TabMovedMsg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// closeRequested() vrátí tea.Cmd, který pošle msg
+func closeRequested(msg TabCloseRequestedMsg) tea.Cmd {
+	return func() tea.Msg {
+		return msg
+	}
 }
 
 // View() je standardní funkce pro bubbletea
@@ -201,81 +747,837 @@ func (m TabsModel) View() string {
 
 	var s string
 
-	b := m.borderType.TopLeft
-	b += strings.Repeat(m.borderType.Top, m.width-2)
-	b += m.borderType.TopRight
-	s = m.borderStyle.Render(b) + "\n" + s
+	b := m.borderType
+	rows := m.rows()
+	top := min(m.scrollTop, len(rows))
+	end := m.visibleEnd(rows, top)
+	tabRows := m.tabRows()
 
-	for i, tab := range m.tabs {
-		t := m.borderStyle.Render(m.borderType.Left)
+	selected := func(k int) bool {
+		return rows[k].tab >= 0 && rows[k].tab == m.selectedTab
+	}
 
-		var w string
-		if len([]rune(tab)) > m.width-3 {
-			r := []rune(tab)
-			r = r[:m.width-5]
-			w = string(r) + ".."
-		} else {
-			w = tab
+	var mark string
+	if top > 0 {
+		mark = "▲"
+	}
+	left, right := m.attach(b.TopLeft, b.TopRight, b.MiddleTop, b.MiddleTop)
+	if top < end && selected(top) {
+		left, right = m.attach(b.TopLeft, b.TopRight, b.Top, b.Top)
+	}
+	var labels []string
+	if m.positionOnTop {
+		labels = m.positionLabels()
+	}
+	s = m.borderLine(left, b.Top, right, mark, labels...) + "\n"
+
+	used := 1
+	for k := top; k < end; k++ {
+		used += rowHeight(rows[k], tabRows)
+	}
+
+	fill := 0
+	if m.attached != SideNone {
+		fill = max(m.height-used, 0)
+	}
+
+	for k := top; k < end; k++ {
+		i := rows[k].tab
+
+		left, right := m.borderStyle.Render(b.Left), m.borderStyle.Render(b.Left)
+
+		var t string
+		switch {
+		case i < 0:
+			t = left + m.viewSeparator(rows[k].label) + right
+
+		case i == m.selectedTab:
+			style := m.tabStyleOf(i)
+			opening := style.Width(1).Render(" ")
+			left, right = m.attach(left, right, opening, opening)
+			t = left + m.viewLabel(i, style) + style.Width(1).Render(">") + right
+
+		default:
+			t = left + m.viewLabel(i, m.tabStyleOf(i)) + right
 		}
 
-		if i == m.selectedTab {
-			w = m.selectedTabStyle.Render(w)
-			t += w + m.selectedTabStyle.Width(1).Render(">")
-			t += m.borderStyle.Render(m.borderType.Left)
-		} else {
-			w = m.tabStyle.Render(w)
-			t += w + m.borderStyle.Render(m.borderType.Left)
+		if i >= 0 && tabRows == 2 {
+			t += "\n" + left + m.viewDescription(i) + right
 		}
 
-		if i < len(m.tabs)-1 {
-			t += "\n" + m.borderStyle.Render(m.borderType.MiddleLeft)
-			t += m.borderStyle.Render(strings.Repeat(m.borderType.Bottom, m.width-2))
-			t += m.borderStyle.Render(m.borderType.MiddleRight) + "\n"
+		if k < end-1 {
+			left, right = b.MiddleLeft, b.MiddleRight
+			switch {
+			case selected(k):
+				left, right = m.attach(left, right, b.TopRight, b.TopLeft)
+			case selected(k + 1):
+				left, right = m.attach(left, right, b.BottomRight, b.BottomLeft)
+			}
+			t += "\n" + m.borderLine(left, b.Bottom, right, "") + "\n"
 		} else {
-			t += "\n" + m.borderStyle.Render(m.borderType.BottomLeft)
-			t += m.borderStyle.Render(strings.Repeat(m.borderType.Bottom, m.width-2))
-			t += m.borderStyle.Render(m.borderType.BottomRight) + "\n"
+			mark = ""
+			if end < len(rows) {
+				mark = "▼"
+			}
+
+			left, right = b.BottomLeft, b.BottomRight
+			switch {
+			case fill > 0 && selected(k):
+				left, right = m.attach(left, right, b.TopRight, b.TopLeft)
+			case fill > 0:
+				left, right = m.attach(left, right, b.MiddleRight, b.MiddleLeft)
+			case selected(k):
+				left, right = m.attach(left, right, b.Bottom, b.Bottom)
+			default:
+				left, right = m.attach(left, right, b.MiddleBottom, b.MiddleBottom)
+			}
+			labels = nil
+			if !m.positionOnTop {
+				labels = m.positionLabels()
+			}
+			t += "\n" + m.borderLine(left, b.Bottom, right, mark, labels...) + "\n"
 		}
 
 		s += t
 
 	}
 
+	for i := range fill {
+		edge := b.Left
+		if i == fill-1 {
+			edge = b.BottomLeft
+			if m.attached == SideLeft {
+				edge = b.BottomRight
+			}
+		}
+
+		space := strings.Repeat(" ", m.width-1)
+		if m.attached == SideLeft {
+			s += m.borderStyle.Render(edge) + space + "\n"
+		} else {
+			s += space + m.borderStyle.Render(edge) + "\n"
+		}
+	}
+
 	return s
 }
 
+// attach() vrátí znaky levého a pravého okraje, kde je znak na připojené straně
+// (viz WithAttached()) nahrazený znakem forRight, resp. forLeft
+func (m TabsModel) attach(left, right, forRight, forLeft string) (string, string) {
+	switch m.attached {
+	case SideRight:
+		return left, forRight
+	case SideLeft:
+		return forLeft, right
+	}
+
+	return left, right
+}
+
+// ViewAttached() vrátí View() spojené s obsahem content (např. View() okna
+// window.WindowModel) na připojené straně, viz WithAttached()
+// Sloupec okraje content přiléhající k záložkám se vynechá, společným okrajem je
+// okraj záložek, content proto musí být o jeden sloupec širší
+// Bez WithAttached() záložky s obsahem jen spojí vedle sebe
+func (m TabsModel) ViewAttached(content string) string {
+	tabs := strings.TrimSuffix(m.View(), "\n")
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		switch m.attached {
+		case SideRight:
+			lines[i] = ansi.TruncateLeft(line, 1, "")
+		case SideLeft:
+			lines[i] = ansi.Truncate(line, ansi.StringWidth(line)-1, "")
+		}
+	}
+	content = strings.Join(lines, "\n")
+
+	if m.attached == SideLeft {
+		return lipgloss.JoinHorizontal(lipgloss.Top, content, tabs)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs, content)
+}
+
+// ViewContent() vrátí View() modelu obsahu vybrané záložky, viz RegisterContent()
+// Pokud vybraná záložka nemá zaregistrovaný obsah, vrátí ""
+//
+// V hlavním View() použít např.:
+//
+//	s := lipgloss.JoinHorizontal(lipgloss.Left, m.tabs.View(), m.tabs.ViewContent())
+func (m TabsModel) ViewContent() string {
+	content, ok := m.contents[m.selectedTab]
+	if !ok {
+		return ""
+	}
+
+	return content.View()
+}
+
+// viewSeparator() vykreslí oddělovač s textem label, viz AddSeparator()
+// Text delší než šířka záložek se zkrátí
+func (m TabsModel) viewSeparator(label string) string {
+	if ansi.StringWidth(label) > m.width-2 {
+		label = ansi.Truncate(label, max(m.width-2, 0), "..")
+	}
+
+	return m.separatorStyle.Render(label)
+}
+
+// viewDescription() vykreslí popis záložky i, viz SetTabDescription()
+// Popis delší než šířka záložky se zkrátí
+func (m TabsModel) viewDescription(i int) string {
+	desc := m.descs[i]
+	if ansi.StringWidth(desc) > m.width-2 {
+		desc = ansi.Truncate(desc, max(m.width-2, 0), "..")
+	}
+
+	style := m.descriptionStyle
+	if i == m.selectedTab {
+		style = style.Inherit(m.tabStyleOf(i))
+	}
+
+	return style.Render(desc)
+}
+
+// viewLabel() vykreslí text záložky i i s předponou stylem style
+// Text delší než šířka záložky se zkrátí podle šířky zobrazení, široké znaky se
+// nerozdělují, předpona zůstává celá, pokud se do šířky vejde
+func (m TabsModel) viewLabel(i int, style lipgloss.Style) string {
+	prefix := m.prefixes[i]
+	if prefix != "" {
+		prefix += " "
+	}
+
+	name := m.tabs[i]
+	if avail := m.width - 3 - ansi.StringWidth(prefix); ansi.StringWidth(name) > avail {
+		name = ansi.Truncate(name, max(avail, 0), "..")
+	}
+	if avail := m.width - 3; ansi.StringWidth(prefix) > avail {
+		prefix, name = ansi.Truncate(prefix, max(avail, 0), ""), ""
+	}
+
+	if prefix == "" {
+		return style.Render(name)
+	}
+
+	width := m.width - 2
+	if i == m.selectedTab {
+		width = m.width - 3
+	}
+
+	plain := style.UnsetWidth().UnsetAlign()
+	label := m.prefixStyle.Inherit(plain).Render(prefix) + plain.Render(name)
+
+	gap := max(width-lipgloss.Width(label), 0)
+	left := gap / 2
+
+	return plain.Render(strings.Repeat(" ", left)) + label + plain.Render(strings.Repeat(" ", gap-left))
+}
+
 // GetTabs() vrátí všechny nastavené záložky
 func (m TabsModel) GetTabs() []string {
 	return m.tabs
 }
 
+// tabStyleOf() vrátí styl záložky i podle WithTabStyleFunc(), nebo podle stylu
+// vybrané, neaktivní a nevybrané záložky
+func (m TabsModel) tabStyleOf(i int) lipgloss.Style {
+	selected := i == m.selectedTab
+
+	if m.tabStyleFunc != nil {
+		width := m.width - 2
+		if selected {
+			width = m.width - 3
+		}
+		return m.tabStyleFunc(i, m.tabs[i], selected).Width(width).Align(lipgloss.Center)
+	}
+
+	switch {
+	case selected:
+		return m.selectedTabStyle
+	case m.disabled[i]:
+		return m.disabledTabStyle
+	}
+
+	return m.tabStyle
+}
+
+// GetVisibleTabRange() vrátí rozsah zobrazených záložek, start je index první
+// zobrazené záložky, end index za poslední zobrazenou záložkou
+// Záložky, které se nevejdou do výšky, se nezobrazí, seznam se posouvá tak, aby
+// byla vybraná záložka vždy vidět
+func (m TabsModel) GetVisibleTabRange() (start, end int) {
+	rows := m.rows()
+	top := min(m.scrollTop, len(rows))
+
+	start = -1
+	for _, r := range rows[top:m.visibleEnd(rows, top)] {
+		if r.tab < 0 {
+			continue
+		}
+		if start < 0 {
+			start = r.tab
+		}
+		end = r.tab + 1
+	}
+
+	if start < 0 {
+		start = 0
+		for _, r := range rows[:top] {
+			if r.tab >= 0 {
+				start = r.tab + 1
+			}
+		}
+		end = start
+	}
+
+	return start, end
+}
+
 // GetSelectedTab() vrátí vybranou záložku
 func (m TabsModel) GetSelectedTab() int {
 	return m.selectedTab
 }
 
+// GetSelectedTabName() vrátí text vybrané záložky
+// Vrací false, pokud model nemá žádné záložky
+func (m TabsModel) GetSelectedTabName() (string, bool) {
+	if m.selectedTab < 0 || m.selectedTab >= len(m.tabs) {
+		return "", false
+	}
+
+	return m.tabs[m.selectedTab], true
+}
+
+// IndexOf() vrátí index první záložky s textem name
+// Vrací false, pokud záložka s textem name neexistuje
+func (m TabsModel) IndexOf(name string) (int, bool) {
+	i := slices.Index(m.tabs, name)
+
+	return i, i >= 0
+}
+
+// HasTab() vrátí true, pokud existuje záložka s textem name
+func (m TabsModel) HasTab(name string) bool {
+	return slices.Contains(m.tabs, name)
+}
+
 // SetSelectedTab() nastaví vybranou záložku
+// Index mimo rozsah se omezí na první/poslední záložku, bez záložek nedělá nic
+// Pokud je použito WithEmitOnSet(true) a vybraná záložka se změní, vrátí nejbližší
+// volání Update() tea.Cmd s TabChangedMsg
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
 func (m TabsModel) SetSelectedTab(t int) TabsModel {
-	m.selectedTab = t
+	if len(m.tabs) == 0 {
+		return m
+	}
+
+	oldTab := m.selectedTab
+	m.selectedTab = max(min(t, len(m.tabs)-1), 0)
+	m = m.scrollToSelected()
+
+	if m.emitOnSet && m.selectedTab != oldTab {
+		if m.pendingChange != nil {
+			oldTab = m.pendingChange.OldIndex
+		}
+		msg := m.tabChangedMsg(oldTab)
+		m.pendingChange = &msg
+	}
+
 	return m
 }
 
+// SelectTabByName() vybere první záložku s textem name
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu a false,
+// pokud záložka s textem name neexistuje (výběr se pak nemění)
+func (m TabsModel) SelectTabByName(name string) (TabsModel, bool) {
+	i, ok := m.IndexOf(name)
+	if !ok {
+		return m, false
+	}
+
+	return m.SetSelectedTab(i), true
+}
+
 // SetTabs() nastaví nové záložky
 // Pokud je délka textu tabů větší než šířka, zkracuje se jejich text
+// Vybraná záložka zůstane vybraná, pokud je v nových záložkách záložka se stejným
+// textem, jinak se index omezí na poslední záložku
+// Údaje záložek (neaktivní záložky, předpony, popisy, ...) se přesunou se
+// záložkou se stejným textem, údaje záložek, které v nových záložkách nejsou, se
+// zahodí
+// S WithKeepIndexes(true) zůstává vybraná záložka i údaje záložek na stejném indexu
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
 func (m TabsModel) SetTabs(tabs ...string) TabsModel {
+	old := m.tabs
 	m.tabs = tabs
 
-	return m
+	if !m.keepIndexes && len(old) > 0 {
+		index := remapTabs(old, tabs)
+
+		m.disabled = reindexTabs(m.disabled, index)
+		m.pinned = reindexTabs(m.pinned, index)
+		m.prefixes = reindexTabs(m.prefixes, index)
+		m.descs = reindexTabs(m.descs, index)
+		m.contents = reindexTabs(m.contents, index)
+
+		if m.selectedTab < len(index) && index[m.selectedTab] >= 0 {
+			m.selectedTab = index[m.selectedTab]
+		}
+	}
+	m.selectedTab = max(min(m.selectedTab, len(m.tabs)-1), 0)
+
+	return m.fitWidth().scrollToSelected()
+}
+
+// remapTabs() vrátí pro každou záložku z old index záložky se stejným textem
+// v tabs, nebo -1, pokud taková záložka není
+// Záložky se stejným textem se přiřazují v pořadí, ve kterém jsou
+func remapTabs(old, tabs []string) []int {
+	index := make([]int, len(old))
+	seen := make(map[string]int, len(old))
+
+	for i, name := range old {
+		index[i] = -1
+
+		n := seen[name]
+		seen[name]++
+		for j, tab := range tabs {
+			if tab != name {
+				continue
+			}
+			if n == 0 {
+				index[i] = j
+				break
+			}
+			n--
+		}
+	}
+
+	return index
+}
+
+// reindexTabs() vrátí údaje záložek s indexy podle index (viz remapTabs())
+// Údaje záložek, které už neexistují, se zahodí, údaje s indexem mimo index
+// zůstávají
+func reindexTabs[V any](tabs map[int]V, index []int) map[int]V {
+	if len(tabs) == 0 {
+		return tabs
+	}
+
+	moved := make(map[int]V, len(tabs))
+	for i, v := range tabs {
+		switch {
+		case i < 0 || i >= len(index):
+			moved[i] = v
+		case index[i] >= 0:
+			moved[index[i]] = v
+		}
+	}
+
+	return moved
 }
 
 // SetSize() nastaví velikost okna
 // Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
 // Pokud je délka textu tabů větší než šířka, zkracuje se jejich text
+// S WithAutoWidth(true) se width ignoruje
 func (m TabsModel) SetSize(width, height int) TabsModel {
-	m.width, m.height = width, height
+	m.height = height
+	if !m.autoWidth {
+		m = m.setWidth(width)
+	}
+
+	return m.scrollToSelected()
+}
+
+// GetPreferredWidth() vrátí šířku, do které se vejdou všechny záložky bez
+// zkracování, včetně okrajů, značky vybrané záložky, předpon a popisů
+func (m TabsModel) GetPreferredWidth() int {
+	longest := 0
+	for i, tab := range m.tabs {
+		w := lipgloss.Width(tab)
+		if prefix := m.prefixes[i]; prefix != "" {
+			w += lipgloss.Width(prefix) + 1
+		}
+		longest = max(longest, w, lipgloss.Width(m.descs[i])-1)
+	}
+	for _, labels := range m.separators {
+		for _, label := range labels {
+			longest = max(longest, lipgloss.Width(label)-1)
+		}
+	}
+
+	return longest + 3
+}
+
+// fitWidth() nastaví šířku podle GetPreferredWidth(), pokud je použito
+// WithAutoWidth(true)
+func (m TabsModel) fitWidth() TabsModel {
+	if !m.autoWidth {
+		return m
+	}
+
+	return m.setWidth(m.GetPreferredWidth())
+}
+
+// setWidth() nastaví šířku modelu a stylů záložek
+func (m TabsModel) setWidth(width int) TabsModel {
+	m.width = width
 
 	m.tabStyle = m.tabStyle.Width(m.width - 2)
+	m.disabledTabStyle = m.disabledTabStyle.Width(m.width - 2)
+	m.descriptionStyle = m.descriptionStyle.Width(m.width - 2)
+	m.separatorStyle = m.separatorStyle.Width(m.width - 2)
 	m.selectedTabStyle = m.selectedTabStyle.Width(m.width - 3)
 
 	return m
 }
+
+// SetTabDisabled() nastaví, jestli je záložka index neaktivní
+// Neaktivní záložka se zobrazí ve stylu WithDisabledTabColors() a při přepínání
+// klávesami se přeskakuje, SetSelectedTab() ji ale vybrat může
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) SetTabDisabled(index int, disabled bool) TabsModel {
+	m.disabled = maps.Clone(m.disabled)
+	if m.disabled == nil {
+		m.disabled = make(map[int]bool)
+	}
+
+	if disabled {
+		m.disabled[index] = true
+	} else {
+		delete(m.disabled, index)
+	}
+
+	return m
+}
+
+// IsTabDisabled() vrátí true, pokud je záložka index neaktivní
+func (m TabsModel) IsTabDisabled(index int) bool {
+	return m.disabled[index]
+}
+
+// SetTabPrefix() nastaví předponu záložky index, např. ikonu, která se zobrazí
+// před textem záložky ve vlastním stylu (viz WithTabPrefixColor())
+// Předpona se započítává do šířky, při zkracování se zkracuje jen text záložky
+// Pro zrušení předpony předat ""
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) SetTabPrefix(index int, prefix string) TabsModel {
+	m.prefixes = maps.Clone(m.prefixes)
+	if m.prefixes == nil {
+		m.prefixes = make(map[int]string)
+	}
+
+	if prefix != "" {
+		m.prefixes[index] = prefix
+	} else {
+		delete(m.prefixes, index)
+	}
+
+	return m.fitWidth()
+}
+
+// GetTabPrefix() vrátí předponu záložky index, "" pokud žádnou nemá
+func (m TabsModel) GetTabPrefix(index int) string {
+	return m.prefixes[index]
+}
+
+// SetTabDescription() nastaví popis záložky index, který se zobrazí na řádku pod
+// textem záložky ve stylu WithDescriptionColors()
+// Pokud má popis alespoň jedna záložka, zabírá každá záložka dva řádky, popisy se
+// ale skryjí, pokud se s nimi do výšky modelu nevejdou všechny záložky
+// Pro zrušení popisu předat ""
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) SetTabDescription(index int, desc string) TabsModel {
+	m.descs = maps.Clone(m.descs)
+	if m.descs == nil {
+		m.descs = make(map[int]string)
+	}
+
+	if desc != "" {
+		m.descs[index] = desc
+	} else {
+		delete(m.descs, index)
+	}
+
+	return m.fitWidth().scrollToSelected()
+}
+
+// GetTabDescription() vrátí popis záložky index, "" pokud žádný nemá
+func (m TabsModel) GetTabDescription(index int) string {
+	return m.descs[index]
+}
+
+// AddSeparator() přidá oddělovač s textem label nad záložku at, např. pro
+// pojmenování skupiny záložek (at mimo rozsah se omezí na začátek nebo konec,
+// len(GetTabs()) přidá oddělovač za poslední záložku)
+// Oddělovač zabírá v seznamu řádek ve stylu WithSeparatorColors(), nelze ho vybrat
+// a při přepínání se přeskakuje, GetTabs() ani indexy záložek ho nezahrnují
+// Oddělovače jsou vázané na pozici, při přesunu záložek ani v SetTabs() se
+// nepřesouvají
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) AddSeparator(label string, at int) TabsModel {
+	at = max(min(at, len(m.tabs)), 0)
+
+	m.separators = maps.Clone(m.separators)
+	if m.separators == nil {
+		m.separators = make(map[int][]string)
+	}
+	m.separators[at] = append(slices.Clone(m.separators[at]), label)
+
+	return m.fitWidth().scrollToSelected()
+}
+
+// ClearSeparators() odebere všechny oddělovače, viz AddSeparator()
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) ClearSeparators() TabsModel {
+	m.separators = nil
+
+	return m.fitWidth().scrollToSelected()
+}
+
+// RegisterContent() zaregistruje model obsahu záložky index
+// Update() pak předává nepřebrané zprávy jen modelu vybrané záložky (kromě
+// tea.WindowSizeMsg, kterou dostanou všechny) a ViewContent() vrací jeho View(),
+// modely ostatních záložek si stav zachovají
+// Pro zrušení registrace předat nil
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) RegisterContent(index int, model tea.Model) TabsModel {
+	m.contents = maps.Clone(m.contents)
+	if m.contents == nil {
+		m.contents = make(map[int]tea.Model)
+	}
+
+	if model != nil {
+		m.contents[index] = model
+	} else {
+		delete(m.contents, index)
+	}
+
+	return m
+}
+
+// GetContent() vrátí model obsahu záložky index, viz RegisterContent()
+// Vrací false, pokud záložka nemá zaregistrovaný obsah
+func (m TabsModel) GetContent(index int) (tea.Model, bool) {
+	content, ok := m.contents[index]
+
+	return content, ok
+}
+
+// SetTabStyleFunc() nastaví funkci pro styl jednotlivých záložek, viz
+// WithTabStyleFunc()
+// Pro zrušení předat nil
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) SetTabStyleFunc(f TabStyleFunc) TabsModel {
+	m.tabStyleFunc = f
+
+	return m
+}
+
+// AddTab() vloží záložku name na pozici at (at mimo rozsah se omezí na začátek
+// nebo konec), vybraná záložka zůstane vybraná
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) AddTab(name string, at int) TabsModel {
+	at = max(min(at, len(m.tabs)), 0)
+
+	m.tabs = slices.Insert(slices.Clone(m.tabs), at, name)
+	m.disabled = shiftTabs(m.disabled, at, 1)
+	m.pinned = shiftTabs(m.pinned, at, 1)
+	m.prefixes = shiftTabs(m.prefixes, at, 1)
+	m.descs = shiftTabs(m.descs, at, 1)
+	m.separators = shiftTabs(m.separators, at, 1)
+	m.contents = shiftTabs(m.contents, at, 1)
+
+	if len(m.tabs) > 1 && m.selectedTab >= at {
+		m.selectedTab++
+	}
+
+	return m.fitWidth().scrollToSelected()
+}
+
+// RemoveTab() odebere záložku index, index mimo rozsah nedělá nic
+// Pokud je odebraná záložka vybraná, vybere se předchozí záložka (první, pokud
+// byla odebrána první) a s WithEmitOnSet(true) vrátí nejbližší volání Update()
+// tea.Cmd s TabChangedMsg
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) RemoveTab(index int) TabsModel {
+	if index < 0 || index >= len(m.tabs) {
+		return m
+	}
+
+	m.tabs = slices.Delete(slices.Clone(m.tabs), index, index+1)
+
+	m.disabled = maps.Clone(m.disabled)
+	delete(m.disabled, index)
+	m.disabled = shiftTabs(m.disabled, index+1, -1)
+
+	m.pinned = maps.Clone(m.pinned)
+	delete(m.pinned, index)
+	m.pinned = shiftTabs(m.pinned, index+1, -1)
+
+	m.prefixes = maps.Clone(m.prefixes)
+	delete(m.prefixes, index)
+	m.prefixes = shiftTabs(m.prefixes, index+1, -1)
+
+	m.descs = maps.Clone(m.descs)
+	delete(m.descs, index)
+	m.descs = shiftTabs(m.descs, index+1, -1)
+
+	// oddělovače nad odebranou záložkou patří k záložce, která je teď na jejím místě
+	separators := m.separators[index]
+	m.separators = maps.Clone(m.separators)
+	delete(m.separators, index)
+	m.separators = shiftTabs(m.separators, index+1, -1)
+	if len(separators) > 0 {
+		m.separators[index] = append(slices.Clone(separators), m.separators[index]...)
+	}
+
+	m.contents = maps.Clone(m.contents)
+	delete(m.contents, index)
+	m.contents = shiftTabs(m.contents, index+1, -1)
+
+	switch {
+	case index < m.selectedTab:
+		m.selectedTab--
+	case index == m.selectedTab:
+		m.selectedTab = max(index-1, 0)
+		if m.emitOnSet && len(m.tabs) > 0 {
+			msg := m.tabChangedMsg(index)
+			if m.pendingChange != nil {
+				msg.OldIndex = m.pendingChange.OldIndex
+			}
+			m.pendingChange = &msg
+			m.pendingRemoved = true
+		}
+	}
+
+	return m.fitWidth().scrollToSelected()
+}
+
+// ConfirmClose() zavře záložku index stejně jako RemoveTab(), typicky jako
+// odpověď na TabCloseRequestedMsg
+// Záložku, kterou nelze zavřít (viz SetTabCloseable()), ani index mimo rozsah
+// neodebere
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) ConfirmClose(index int) TabsModel {
+	if !m.IsTabCloseable(index) {
+		return m
+	}
+
+	return m.RemoveTab(index)
+}
+
+// SetTabCloseable() nastaví, jestli lze záložku index zavřít klávesou pro
+// zavření a ConfirmClose(), ve výchozím stavu lze zavřít všechny záložky
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) SetTabCloseable(index int, closeable bool) TabsModel {
+	m.pinned = maps.Clone(m.pinned)
+	if m.pinned == nil {
+		m.pinned = make(map[int]bool)
+	}
+
+	if closeable {
+		delete(m.pinned, index)
+	} else {
+		m.pinned[index] = true
+	}
+
+	return m
+}
+
+// IsTabCloseable() vrátí true, pokud záložka index existuje a lze ji zavřít
+func (m TabsModel) IsTabCloseable(index int) bool {
+	return index >= 0 && index < len(m.tabs) && !m.pinned[index]
+}
+
+// RenameTab() změní text záložky index na name, index mimo rozsah nedělá nic
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) RenameTab(index int, name string) TabsModel {
+	if index < 0 || index >= len(m.tabs) {
+		return m
+	}
+
+	m.tabs = slices.Clone(m.tabs)
+	m.tabs[index] = name
+
+	return m.fitWidth()
+}
+
+// MoveTab() přesune záložku from na pozici to (to mimo rozsah se omezí na první
+// nebo poslední záložku), from mimo rozsah nedělá nic
+// Vybraná záložka zůstane vybraná i na nové pozici, neaktivní záložky, předpony
+// a další údaje záložek se přesouvají se záložkou
+// Vrací TabsModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m TabsModel) MoveTab(from, to int) TabsModel {
+	if from < 0 || from >= len(m.tabs) {
+		return m
+	}
+	to = max(min(to, len(m.tabs)-1), 0)
+	if from == to {
+		return m
+	}
+
+	tab := m.tabs[from]
+	m.tabs = slices.Insert(slices.Delete(slices.Clone(m.tabs), from, from+1), to, tab)
+
+	m.disabled = moveTabs(m.disabled, from, to)
+	m.pinned = moveTabs(m.pinned, from, to)
+	m.prefixes = moveTabs(m.prefixes, from, to)
+	m.descs = moveTabs(m.descs, from, to)
+	m.contents = moveTabs(m.contents, from, to)
+
+	m.selectedTab = movedIndex(m.selectedTab, from, to)
+
+	return m.scrollToSelected()
+}
+
+// movedIndex() vrátí nový index záložky i po přesunu záložky from na pozici to
+func movedIndex(i, from, to int) int {
+	switch {
+	case i == from:
+		return to
+	case from < to && i > from && i <= to:
+		return i - 1
+	case to < from && i >= to && i < from:
+		return i + 1
+	}
+
+	return i
+}
+
+// moveTabs() vrátí údaje záložek s indexy upravenými po přesunu záložky from na
+// pozici to
+func moveTabs[V any](tabs map[int]V, from, to int) map[int]V {
+	if len(tabs) == 0 {
+		return tabs
+	}
+
+	moved := make(map[int]V, len(tabs))
+	for i, v := range tabs {
+		moved[movedIndex(i, from, to)] = v
+	}
+
+	return moved
+}
+
+// shiftTabs() vrátí údaje záložek, kde jsou indexy >= from posunuté o delta
+func shiftTabs[V any](tabs map[int]V, from, delta int) map[int]V {
+	if len(tabs) == 0 {
+		return tabs
+	}
+
+	shifted := make(map[int]V, len(tabs))
+	for i, v := range tabs {
+		if i >= from {
+			i += delta
+		}
+		shifted[i] = v
+	}
+
+	return shifted
+}
@@ -0,0 +1,216 @@
+package tabs
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// numberedTabs() vrátí n záložek pojmenovaných podle pořadí
+func numberedTabs(n int) []string {
+	tabs := make([]string, n)
+	for i := range tabs {
+		tabs[i] = "Záložka " + strconv.Itoa(i)
+	}
+
+	return tabs
+}
+
+// checkVisible() ověří, že je vybraná záložka vidět, zobrazených záložek je want
+// a výstup není vyšší než model
+func checkVisible(t *testing.T, m TabsModel, want int) {
+	t.Helper()
+
+	start, end := m.GetVisibleTabRange()
+	if selected := m.GetSelectedTab(); selected < start || selected >= end {
+		t.Fatalf("vybraná záložka %d není vidět (%d–%d)", selected, start, end)
+	}
+	if end-start != want {
+		t.Fatalf("zobrazeno %d záložek (%d–%d), chci %d", end-start, start, end, want)
+	}
+	// View() končí novým řádkem
+	if got := strings.Count(m.View(), "\n"); got > m.height {
+		t.Fatalf("výstup má %d řádků, výška je %d", got, m.height)
+	}
+}
+
+func TestScrollTabs(t *testing.T) {
+	const count = 20
+
+	m := NewTabsModel(WithTabs(numberedTabs(count)...)).SetSize(16, 9)
+	page := (9 - 1) / 2
+
+	if start, end := m.GetVisibleTabRange(); start != 0 || end != page {
+		t.Fatalf("po vytvoření GetVisibleTabRange() = %d, %d, chci 0, %d", start, end, page)
+	}
+
+	for i := 1; i < count; i++ {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		checkVisible(t, m, page)
+		if start, _ := m.GetVisibleTabRange(); start != max(i-page+1, 0) {
+			t.Fatalf("dolů na %d: první zobrazená záložka %d, chci %d", i, start, max(i-page+1, 0))
+		}
+	}
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, "▲") {
+		t.Fatalf("na konci chybí značka skrytých záložek nahoře:\n%s", view)
+	}
+
+	for i := count - 2; i >= 0; i-- {
+		m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+		checkVisible(t, m, page)
+	}
+	if start, end := m.GetVisibleTabRange(); start != 0 || end != page {
+		t.Fatalf("zpět nahoře GetVisibleTabRange() = %d, %d, chci 0, %d", start, end, page)
+	}
+
+	m = m.SetSelectedTab(count - 1)
+	checkVisible(t, m, page)
+	m = m.SetSelectedTab(count / 2)
+	checkVisible(t, m, page)
+}
+
+func TestScrollTabsResize(t *testing.T) {
+	m := NewTabsModel(WithTabs(numberedTabs(10)...)).SetSize(16, 21).SetSelectedTab(9)
+	checkVisible(t, m, 10)
+
+	for height := 20; height >= 3; height-- {
+		m = m.SetSize(16, height)
+		checkVisible(t, m, (height-1)/2)
+	}
+}
+
+func TestWideLabels(t *testing.T) {
+	labels := []string{"漢字のタブ", "🙂 emoji 🎉", "žluťoučký kůň", "A", "👨‍👩‍👧 rodina"}
+
+	for _, side := range []Side{SideNone, SideRight, SideLeft} {
+		for width := 4; width <= 24; width++ {
+			m := NewTabsModel(WithTabs(labels...), WithAttached(side)).SetSize(width, 12)
+
+			for i := range labels {
+				m = m.SetSelectedTab(i)
+
+				lines := strings.Split(strings.TrimSuffix(ansi.Strip(m.View()), "\n"), "\n")
+				for _, line := range lines {
+					if got := ansi.StringWidth(line); got != width {
+						t.Fatalf("strana %d, šířka %d, záložka %d: řádek má šířku %d: %q",
+							side, width, i, got, line)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWideLabelsPreferredWidth(t *testing.T) {
+	labels := []string{"漢字のタブ", "🙂 emoji 🎉", "A"}
+
+	m := NewTabsModel(WithTabs(labels...), WithAutoWidth(true)).SetSize(0, 12)
+	view := ansi.Strip(m.View())
+
+	for _, label := range labels {
+		if !strings.Contains(view, label) {
+			t.Fatalf("záložka %q je zkrácená i při preferované šířce %d:\n%s", label, m.GetPreferredWidth(), view)
+		}
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(view, "\n"), "\n") {
+		if got := ansi.StringWidth(line); got != m.GetPreferredWidth() {
+			t.Fatalf("řádek má šířku %d, chci %d: %q", got, m.GetPreferredWidth(), line)
+		}
+	}
+}
+
+// sizeModel je obsah záložky, který si pamatuje poslední velikost a počet zpráv
+type sizeModel struct {
+	width, height int
+	msgs          int
+}
+
+func (s sizeModel) Init() tea.Cmd { return nil }
+
+func (s sizeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	s.msgs++
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		s.width, s.height = size.Width, size.Height
+	}
+
+	return s, nil
+}
+
+func (s sizeModel) View() string { return "" }
+
+func TestContentWindowSize(t *testing.T) {
+	m := NewTabsModel(WithTabs("A", "B", "C")).
+		RegisterContent(0, sizeModel{}).
+		RegisterContent(2, sizeModel{}).
+		SetSize(10, 10)
+
+	m, _, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+
+	for i, want := range map[int]int{0: 2, 2: 1} {
+		content, _ := m.GetContent(i)
+		got := content.(sizeModel)
+		if got.width != 80 || got.height != 24 {
+			t.Fatalf("záložka %d: velikost %dx%d, chci 80x24", i, got.width, got.height)
+		}
+		if got.msgs != want {
+			t.Fatalf("záložka %d: dostala %d zpráv, chci %d", i, got.msgs, want)
+		}
+	}
+}
+
+// changes() vrátí TabChangedMsg z cmd (tea.BatchMsg rozbalí)
+func changes(cmd tea.Cmd) []TabChangedMsg {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case TabChangedMsg:
+		return []TabChangedMsg{msg}
+	case tea.BatchMsg:
+		var ret []TabChangedMsg
+		for _, c := range msg {
+			ret = append(ret, changes(c)...)
+		}
+		return ret
+	}
+
+	return nil
+}
+
+func TestRemoveSelectedTabEmits(t *testing.T) {
+	tests := []struct {
+		name     string
+		selected int
+		remove   int
+		want     []TabChangedMsg
+	}{
+		{"první vybraná", 0, 0, []TabChangedMsg{{OldIndex: 0, NewIndex: 0, Name: "B"}}},
+		{"prostřední vybraná", 1, 1, []TabChangedMsg{{OldIndex: 1, NewIndex: 0, Name: "A"}}},
+		{"jiná záložka", 0, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewTabsModel(WithTabs("A", "B", "C"), WithEmitOnSet(true)).SetSelectedTab(tt.selected)
+			m, _, _ = m.Update(nil)
+
+			m = m.RemoveTab(tt.remove)
+			m, cmd, _ := m.Update(nil)
+			if got := changes(cmd); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("TabChangedMsg = %+v, chci %+v", got, tt.want)
+			}
+
+			if _, cmd, _ = m.Update(nil); changes(cmd) != nil {
+				t.Fatal("změna se poslala dvakrát")
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package window
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles jsou styly všech částí okna, viz WithStyles() a DefaultStyles()
+type Styles struct {
+	Border  lipgloss.Style
+	Title   lipgloss.Style
+	Footer  lipgloss.Style
+	Content lipgloss.Style
+	// Backdrop se použije jen s WithBackdropDim(true), viz ViewOverlay()
+	Backdrop lipgloss.Style
+}
+
+// DefaultStyles() vrátí výchozí styly okna, vhodné jako základ pro úpravy
+// předávané do WithStyles()
+func DefaultStyles() Styles {
+	return Styles{
+		Border:   lipgloss.NewStyle().Bold(true),
+		Title:    lipgloss.NewStyle().Bold(true),
+		Footer:   lipgloss.NewStyle(),
+		Content:  lipgloss.NewStyle(),
+		Backdrop: lipgloss.NewStyle().Faint(true),
+	}
+}
+
+// WithStyles() nastaví styly všech částí okna najednou
+// Nevyplněné styly jsou prázdné (bez barev a zvýraznění), pro změnu jen některých
+// stylů upravit DefaultStyles()
+// Barevné volby With*Colors() použité po WithStyles() přepíší příslušný styl
+func WithStyles(s Styles) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.setStyles(s)
+	}
+}
+
+// setStyles() nastaví styly ze s
+func (m *WindowModel) setStyles(s Styles) {
+	m.borderStyle = s.Border
+	m.titleStyle = s.Title
+	m.footerStyle = s.Footer
+	m.contentStyle = s.Content
+	m.backdropStyle = s.Backdrop
+}
+
+// GetStyles() vrátí aktuální styly okna
+func (m WindowModel) GetStyles() Styles {
+	return Styles{
+		Border:   m.borderStyle,
+		Title:    m.titleStyle,
+		Footer:   m.footerStyle,
+		Content:  m.contentStyle,
+		Backdrop: m.backdropStyle,
+	}
+}
+
+// SetStyles() nastaví styly všech částí okna, viz WithStyles()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetStyles(s Styles) WindowModel {
+	m.setStyles(s)
+
+	return m
+}
@@ -2,10 +2,67 @@
 package window
 
 import (
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	// DefaultKeys je výchozí mapování klávesových zkratek
+	DefaultKeys = Keys{
+		ScrollDown1: "j",
+		ScrollDown2: tea.KeyDown.String(),
+		ScrollUp1:   "k",
+		ScrollUp2:   tea.KeyUp.String(),
+		PageDown1:   tea.KeyCtrlD.String(),
+		PageDown2:   tea.KeyCtrlF.String(),
+		PageDown3:   tea.KeyPgDown.String(),
+		PageUp1:     tea.KeyCtrlU.String(),
+		PageUp2:     tea.KeyCtrlB.String(),
+		PageUp3:     tea.KeyPgUp.String(),
+		Top1:        "g",
+		Top2:        tea.KeyHome.String(),
+		Bottom1:     "G",
+		Bottom2:     tea.KeyEnd.String(),
+	}
+)
+
+// Keys je typ pro definování klávesových zkratek
+// Vychází z bubbletea.KeyMsg.String()
+// Každá akce může mít více klávesových zkratek (ScrollDown1, ScrollDown2, ...)
+// Pokud je nastaveno na "", tak se ignoruje
+type Keys struct {
+	ScrollDown1 string
+	ScrollDown2 string
+	ScrollDown3 string
+	ScrollUp1   string
+	ScrollUp2   string
+	ScrollUp3   string
+	PageDown1   string
+	PageDown2   string
+	PageDown3   string
+	PageUp1     string
+	PageUp2     string
+	PageUp3     string
+	Top1        string
+	Top2        string
+	Top3        string
+	Bottom1     string
+	Bottom2     string
+	Bottom3     string
+}
+
+// Edge je okraj okna, viz WithTitlePosition()
+type Edge int
+
+const (
+	// EdgeTop je horní okraj okna
+	EdgeTop Edge = iota
+	// EdgeBottom je spodní okraj okna
+	EdgeBottom
 )
 
 // WindowModel je model pro použití v bubbletea aplikaci
@@ -14,16 +71,41 @@ import (
 type WindowModel struct {
 	width, height int
 
-	title   string
-	content string
+	autoSize                 bool
+	sizeOffsetW, sizeOffsetH int
+
+	screenWidth, screenHeight int
+	placeH, placeV            lipgloss.Position
+	backdropDim               bool
+	backdropStyle             lipgloss.Style
+
+	keys Keys
+
+	title     string
+	footer    string
+	content   string
+	scrollTop int
 
 	borderType   lipgloss.Border
 	borderStyle  lipgloss.Style
 	titleStyle   lipgloss.Style
+	footerStyle  lipgloss.Style
 	contentStyle lipgloss.Style
 
+	footerPos lipgloss.Position
+
+	// styly okraje a titulku podle focusu, nil znamená borderStyle a titleStyle
+	focused                                bool
+	focusedBorderStyle, blurredBorderStyle *lipgloss.Style
+	focusedTitleStyle, blurredTitleStyle   *lipgloss.Style
+
+	titleEdge             Edge
+	titlePos              lipgloss.Position
+	titleOpen, titleClose string
+
 	contentVPos, contentHPos lipgloss.Position
 	contentPadding           int
+	wrap                     bool
 }
 
 // NewWindowModel() je funkce pro vytvoření nového WindowModelu
@@ -31,13 +113,19 @@ type WindowModel struct {
 // Pro nastavení vlastností modelu použít jako parametry funkce WithTitle a další
 func NewWindowModel(options ...func(*WindowModel)) WindowModel {
 	m := WindowModel{
-		borderType:   lipgloss.RoundedBorder(),
-		borderStyle:  lipgloss.NewStyle().Bold(true),
-		titleStyle:   lipgloss.NewStyle().Bold(true),
-		contentStyle: lipgloss.NewStyle(),
-		contentVPos:  lipgloss.Center,
-		contentHPos:  lipgloss.Center,
+		keys:        DefaultKeys,
+		borderType:  lipgloss.RoundedBorder(),
+		footerPos:   lipgloss.Center,
+		titlePos:    lipgloss.Center,
+		titleOpen:   "[",
+		titleClose:  "]",
+		contentVPos: lipgloss.Center,
+		contentHPos: lipgloss.Center,
+		wrap:        true,
+		placeH:      lipgloss.Center,
+		placeV:      lipgloss.Center,
 	}
+	m.setStyles(DefaultStyles())
 
 	for _, opt := range options {
 		opt(&m)
@@ -46,6 +134,71 @@ func NewWindowModel(options ...func(*WindowModel)) WindowModel {
 	return m
 }
 
+// WithKeys() definuje vlastní klávesové zkratky modelu
+// Jako argument předat typ Keys
+// Pokud není použito, model použije výchozí klávesy definované v DefaultKeys
+func WithKeys(keys Keys) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.keys = keys
+	}
+}
+
+// WithAutoSize() nastaví, že si okno velikost nastavuje samo podle
+// tea.WindowSizeMsg předané do Update(), zmenšenou o WithSizeOffset()
+// Do první tea.WindowSizeMsg platí velikost nastavená pomocí SetSize()
+// Pokud není použito, velikost se nastavuje jen pomocí SetSize()
+func WithAutoSize(auto bool) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.autoSize = auto
+	}
+}
+
+// WithSizeOffset() nastaví počet sloupců dw a řádků dh, o které se při
+// WithAutoSize(true) zmenší velikost okna oproti velikosti terminálu, např. pro
+// místo na další komponenty
+func WithSizeOffset(dw, dh int) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.sizeOffsetW, wm.sizeOffsetH = dw, dh
+	}
+}
+
+// WithPlacement() definuje umístění okna přes pozadí ve ViewOverlay(), 0 je
+// vlevo/nahoře, 1 je vpravo/dole (např. lipgloss.Center, 0.3 umístí okno do horní
+// třetiny)
+// Pokud není použito, je okno uprostřed
+func WithPlacement(h, v lipgloss.Position) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.placeH, wm.placeV = h, v
+	}
+}
+
+// WithScreenSize() nastaví velikost obrazovky pro ViewOverlay() do první
+// tea.WindowSizeMsg předané do Update(), podle které se pak velikost obrazovky
+// nastavuje sama
+// Pokud velikost obrazovky není známá, použije se velikost pozadí
+func WithScreenSize(width, height int) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.screenWidth, wm.screenHeight = width, height
+	}
+}
+
+// WithBackdropDim() nastaví, že ViewOverlay() zobrazí pozadí kolem okna bez
+// původních stylů ve stylu WithBackdropStyle()
+// Pokud není použito, pozadí se zobrazí beze změny
+func WithBackdropDim(dim bool) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.backdropDim = dim
+	}
+}
+
+// WithBackdropStyle() definuje styl pozadí pro WithBackdropDim()
+// Pokud není použito, pozadí je ztlumené (Faint)
+func WithBackdropStyle(style lipgloss.Style) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.backdropStyle = style
+	}
+}
+
 // WithTitle() definuje titulek okna
 // Pokud není použito nebo je titulek == "", tak se nezobrazuje
 func WithTitle(title string) func(*WindowModel) {
@@ -54,6 +207,52 @@ func WithTitle(title string) func(*WindowModel) {
 	}
 }
 
+// WithFooter() definuje patičku okna ve spodním okraji, např. nápovědu ke klávesám
+// Pokud není použito nebo je patička == "", tak se nezobrazuje
+func WithFooter(footer string) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.footer = footer
+	}
+}
+
+// WithFooterColors() nastaví barvu patičky okna
+func WithFooterColors(fg, bg lipgloss.Color) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.footerStyle = lipgloss.NewStyle().
+			Foreground(fg).Background(bg)
+	}
+}
+
+// WithFooterAlign() nastaví zarovnání patičky ve spodním okraji (lipgloss.Left,
+// lipgloss.Center nebo lipgloss.Right)
+// Pokud není použito, je patička uprostřed
+func WithFooterAlign(pos lipgloss.Position) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.footerPos = pos
+	}
+}
+
+// WithTitlePosition() nastaví, ve kterém okraji okna je titulek (EdgeTop nebo
+// EdgeBottom) a jeho zarovnání (lipgloss.Left, lipgloss.Center nebo lipgloss.Right)
+// Pokud je titulek ve spodním okraji spolu s patičkou a nevejdou se vedle sebe,
+// zobrazí se jen titulek
+// Pokud není použito, je titulek uprostřed horního okraje
+func WithTitlePosition(edge Edge, align lipgloss.Position) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.titleEdge = edge
+		wm.titlePos = align
+	}
+}
+
+// WithTitleDelimiters() nastaví znaky, kterými je v okraji obalený titulek
+// a patička, např. "┤ " a " ├"
+// Pokud není použito, jsou titulek i patička v hranatých závorkách
+func WithTitleDelimiters(open, close string) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.titleOpen, wm.titleClose = open, close
+	}
+}
+
 // WithContent() nastaví obsah okna
 func WithContent(content string) func(*WindowModel) {
 	return func(wm *WindowModel) {
@@ -87,6 +286,50 @@ func WithBorderColors(fg, bg lipgloss.Color) func(*WindowModel) {
 	}
 }
 
+// WithFocusedBorderColors() nastaví barvy okraje okna, které má focus, viz Focus()
+// Pokud není použito, má okno s focusem okraj podle WithBorderColors()
+func WithFocusedBorderColors(fg, bg lipgloss.Color) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		style := lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Bold(true)
+		wm.focusedBorderStyle = &style
+	}
+}
+
+// WithBlurredBorderColors() nastaví barvy okraje okna, které nemá focus, viz Blur()
+// Pokud není použito, má okno bez focusu okraj podle WithBorderColors()
+func WithBlurredBorderColors(fg, bg lipgloss.Color) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		style := lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Bold(true)
+		wm.blurredBorderStyle = &style
+	}
+}
+
+// WithFocusedTitleColors() nastaví barvu titulku okna, které má focus, viz Focus()
+// Pokud není použito, má okno s focusem titulek podle WithTitleColors()
+func WithFocusedTitleColors(fg, bg lipgloss.Color) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		style := lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Bold(true)
+		wm.focusedTitleStyle = &style
+	}
+}
+
+// WithBlurredTitleColors() nastaví barvu titulku okna, které nemá focus, viz Blur()
+// Pokud není použito, má okno bez focusu titulek podle WithTitleColors()
+func WithBlurredTitleColors(fg, bg lipgloss.Color) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		style := lipgloss.NewStyle().
+			Foreground(fg).Background(bg).
+			Bold(true)
+		wm.blurredTitleStyle = &style
+	}
+}
+
 // WithContentColors() nastaví barvy obsahu okna
 func WithContentColors(fg, bg lipgloss.Color) func(*WindowModel) {
 	return func(wm *WindowModel) {
@@ -104,6 +347,16 @@ func WithContentPosition(vertical, horizontal lipgloss.Position) func(*WindowMod
 	}
 }
 
+// WithWrap() nastaví, jestli se řádky obsahu delší než šířka okna zalamují po
+// slovech (podle šířky zobrazení), nebo se oříznou
+// Zalomený obsah, který se nevejde do výšky okna, lze posouvat, viz Update()
+// Pokud není použito, obsah se zalamuje
+func WithWrap(wrap bool) func(*WindowModel) {
+	return func(wm *WindowModel) {
+		wm.wrap = wrap
+	}
+}
+
 // WithContentPadding() nastaví okraje okna
 func WithContentPadding(p int) func(*WindowModel) {
 	return func(wm *WindowModel) {
@@ -116,67 +369,313 @@ func WithContentPadding(p int) func(*WindowModel) {
 //
 // Použití v hlavním modelu - na začátku funkce Update() zavolat:
 //
-//	m.win, cmd, msg = m.win.Update(msg)
+//	m.win, msg = m.win.Update(msg)
+//
+// Pokud se obsah do okna nevejde, lze ho posouvat klávesami (viz WithKeys()),
+// které si model přebere a nepošle je dál. Ostatní tea.KeyMsg i tea.Msg posílá
+// zpět, stejně jako všechny klávesy, pokud se obsah do okna vejde
+// Z tea.WindowSizeMsg si pamatuje velikost obrazovky pro ViewOverlay() a
+// s WithAutoSize(true) podle ní nastaví velikost okna, zprávu posílá také dál
 func (m WindowModel) Update(msg tea.Msg) (WindowModel, tea.Msg) {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		m.screenWidth, m.screenHeight = msg.Width, msg.Height
+		if m.autoSize {
+			m = m.SetSize(
+				max(msg.Width-m.sizeOffsetW, 0),
+				max(msg.Height-m.sizeOffsetH, 0),
+			)
+		}
+
+	case tea.KeyMsg:
+		if m.innerHeight() <= 0 || len(m.contentLines()) <= m.innerHeight() {
+			break
+		}
+
+		switch msg.String() {
+
+		case m.keys.ScrollDown1, m.keys.ScrollDown2, m.keys.ScrollDown3:
+			m = m.ViewScroll(1)
+
+		case m.keys.ScrollUp1, m.keys.ScrollUp2, m.keys.ScrollUp3:
+			m = m.ViewScroll(-1)
+
+		case m.keys.PageDown1, m.keys.PageDown2, m.keys.PageDown3:
+			m = m.PageScroll(1)
+
+		case m.keys.PageUp1, m.keys.PageUp2, m.keys.PageUp3:
+			m = m.PageScroll(-1)
+
+		case m.keys.Top1, m.keys.Top2, m.keys.Top3:
+			m = m.ScrollToTop()
+
+		case m.keys.Bottom1, m.keys.Bottom2, m.keys.Bottom3:
+			m = m.ScrollToBottom()
+
+		default:
+			return m, msg
+
+		}
+
+		return m, nil
+	}
+
 	return m, msg
 }
 
 // View() je standardní funkce pro bubbletea
 // Volat v hlavním modelu a výsledek spojit s ostatním výstupem
+// Okraj a titulek má okno podle toho, jestli má focus, viz Focus()
 func (m WindowModel) View() string {
 	var s string
 
-	s = m.contentStyle.
-		Padding(m.contentPadding).
-		Width(m.width - 2).Height(m.height - 2).
-		MaxWidth(m.width - 2).MaxHeight(m.height - 2).
-		AlignVertical(m.contentVPos).
-		AlignHorizontal(m.contentHPos).
-		Render(m.content)
+	border, title := m.blurredBorderStyle, m.blurredTitleStyle
+	if m.focused {
+		border, title = m.focusedBorderStyle, m.focusedTitleStyle
+	}
+	if border != nil {
+		m.borderStyle = *border
+	}
+	if title != nil {
+		m.titleStyle = *title
+	}
+
+	if lines := m.contentLines(); len(lines) > m.innerHeight() {
+		top := max(min(m.scrollTop, len(lines)-m.innerHeight()), 0)
+		s = strings.Join(lines[top:top+m.innerHeight()], "\n")
+	} else {
+		s = m.contentStyle.
+			Padding(m.contentPadding).
+			Width(m.width - 2).Height(m.height - 2).
+			MaxWidth(m.width - 2).MaxHeight(m.height - 2).
+			AlignVertical(m.contentVPos).
+			AlignHorizontal(m.contentHPos).
+			Render(m.viewContent())
+	}
 
 	s = m.addBorders(s)
 
 	return s
 }
 
+// ViewOverlay() vrátí okno vykreslené přes background podle WithPlacement(), např.
+// jako vyskakovací okno přes zbytek aplikace
+// Pozadí se doplní nebo ořízne na velikost obrazovky (viz WithScreenSize()),
+// s WithBackdropDim(true) se pozadí kolem okna ztlumí
+//
+// V hlavním View() použít např.:
+//
+//	return m.win.ViewOverlay(m.table.View())
+func (m WindowModel) ViewOverlay(background string) string {
+	win := m.View()
+	bg := m.viewBackdrop(background)
+	x, y := Place(lipgloss.Width(bg), lipgloss.Height(bg), lipgloss.Width(win), lipgloss.Height(win), m.placeH, m.placeV)
+
+	return Overlay(bg, win, x, y)
+}
+
+// Place() vrátí pozici levého horního rohu okna velikosti width × height na
+// pozadí velikosti bgWidth × bgHeight, h a v jsou pozice jako v lipgloss.Place()
+// Okno větší než pozadí je vždy na pozici 0
+func Place(bgWidth, bgHeight, width, height int, h, v lipgloss.Position) (x, y int) {
+	place := func(gap int, pos lipgloss.Position) int {
+		return int(float64(max(gap, 0)) * min(max(float64(pos), 0), 1))
+	}
+
+	return place(bgWidth-width, h), place(bgHeight-height, v)
+}
+
+// viewBackdrop() vrátí pozadí za oknem velikosti obrazovky (pokud není známá,
+// velikosti background), s WithBackdropDim(true) ztlumené
+func (m WindowModel) viewBackdrop(background string) string {
+	lines := strings.Split(background, "\n")
+
+	width, height := m.screenWidth, m.screenHeight
+	if width <= 0 {
+		width = lipgloss.Width(background)
+	}
+	if height <= 0 {
+		height = len(lines)
+	}
+
+	out := make([]string, height)
+	for n := range out {
+		var line string
+		if n < len(lines) {
+			line = ansi.Truncate(lines[n], width, "")
+		}
+		if m.backdropDim {
+			line = ansi.Strip(line)
+		}
+		line += strings.Repeat(" ", max(width-lipgloss.Width(line), 0))
+
+		if m.backdropDim {
+			line = m.backdropStyle.Render(line)
+		}
+		out[n] = line
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// Overlay() vykreslí fg přes bg tak, že levý horní roh fg je na pozici x, y
+// Části fg mimo řádky bg se zahodí, styly bg vedle fg zůstanou zachované
+func Overlay(bg, fg string, x, y int) string {
+	bgLines := strings.Split(bg, "\n")
+
+	for n, line := range strings.Split(fg, "\n") {
+		l := y + n
+		if l < 0 || l >= len(bgLines) {
+			continue
+		}
+
+		left := ansi.Truncate(bgLines[l], x, "")
+		if pad := x - lipgloss.Width(left); pad > 0 {
+			left += strings.Repeat(" ", pad)
+		}
+		right := ansi.TruncateLeft(bgLines[l], x+lipgloss.Width(line), "")
+
+		bgLines[l] = left + ansi.ResetStyle + line + ansi.ResetStyle + right
+	}
+
+	return strings.Join(bgLines, "\n")
+}
+
+// innerHeight() vrátí výšku obsahu okna bez okrajů, nikdy ne zápornou
+func (m WindowModel) innerHeight() int {
+	return max(m.height-2, 0)
+}
+
+// contentLines() vrátí řádky obsahu zalomené na šířku okna, včetně okrajů obsahu
+// (viz WithContentPadding())
+func (m WindowModel) contentLines() []string {
+	s := m.contentStyle.
+		Padding(m.contentPadding).
+		Width(m.width - 2).
+		MaxWidth(m.width - 2).
+		AlignHorizontal(m.contentHPos).
+		Render(m.viewContent())
+
+	return strings.Split(s, "\n")
+}
+
+// viewContent() vrátí obsah okna, s WithWrap(false) s řádky oříznutými na šířku
+// obsahu okna
+func (m WindowModel) viewContent() string {
+	if m.wrap {
+		return m.content
+	}
+
+	width := max(m.width-2-2*m.contentPadding, 0)
+
+	lines := strings.Split(m.content, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func (m WindowModel) addBorders(content string) string {
 	var s string
 
-	borderTop := m.borderType.TopLeft
-	if m.title == "" {
-		borderTop += strings.Repeat(m.borderType.Top, m.width-2)
-		borderTop += m.borderStyle.Render(m.borderType.TopRight)
-	} else {
-		t := m.title
-		if len([]rune(m.title)) > m.width-4 {
-			t = m.title[:m.width-7] + "..."
-		}
+	title := borderLabel{text: m.title, style: m.titleStyle, pos: m.titlePos}
+	footer := borderLabel{text: m.footer, style: m.footerStyle, pos: m.footerPos}
 
-		o := len([]rune(t)) % 2
-		borderTop += strings.Repeat(
-			m.borderType.Top,
-			((m.width-1)/2)-(len([]rune(t))/2)-1,
-		)
-		borderTop += "[" + m.titleStyle.Render(t) + m.borderStyle.Render("]")
-		borderTop += m.borderStyle.Render(strings.Repeat(
-			m.borderType.Top,
-			m.width-((m.width-1)/2)-(len([]rune(t))/2)-3-o,
-		))
-		borderTop += m.borderStyle.Render(m.borderType.TopRight)
+	var top, bottom []borderLabel
+	if m.titleEdge == EdgeBottom {
+		bottom = append(bottom, title)
+	} else {
+		top = append(top, title)
 	}
-	borderTop = m.borderStyle.Render(borderTop)
+	bottom = append(bottom, footer)
 
 	s = lipgloss.NewStyle().
 		BorderStyle(m.borderType).
 		BorderTop(false).
-		BorderBottom(true).
+		BorderBottom(false).
 		BorderLeft(true).
 		BorderRight(true).
 		BorderBackground(m.borderStyle.GetBackground()).
 		BorderForeground(m.borderStyle.GetForeground()).
 		Render(content)
 
-	s = lipgloss.JoinVertical(lipgloss.Top, borderTop, s)
+	s = lipgloss.JoinVertical(lipgloss.Top,
+		m.borderLine(m.borderType.TopLeft, m.borderType.Top, m.borderType.TopRight, top...),
+		s,
+		m.borderLine(m.borderType.BottomLeft, m.borderType.Bottom, m.borderType.BottomRight, bottom...),
+	)
+
+	return s
+}
+
+// borderLabel je text v okraji okna (titulek nebo patička)
+// Text delší než okraj se zkrátí a doplní o "…"
+type borderLabel struct {
+	text  string
+	style lipgloss.Style
+	pos   lipgloss.Position
+}
+
+// borderLine() vykreslí vodorovný okraj ze znaků line mezi rohy left a right
+// s texty labels obalenými oddělovači (viz WithTitleDelimiters()), zarovnanými
+// podle pos
+// Mezi rohem a textem zůstane alespoň jeden znak okraje, pokud je na něj místo,
+// text, ze kterého by po zkrácení nezbyl ani jeden znak nebo který by se překrýval
+// s předchozím textem, se nezobrazí
+func (m WindowModel) borderLine(left, line, right string, labels ...borderLabel) string {
+	type span struct {
+		start, end int
+		s          string
+	}
+
+	inner := max(m.width-2, 0)
+	delims := ansi.StringWidth(m.titleOpen) + ansi.StringWidth(m.titleClose)
+
+	var spans []span
+	for _, l := range labels {
+		if l.text == "" {
+			continue
+		}
+
+		text, avail := l.text, inner-delims
+		if ansi.StringWidth(text) > avail {
+			if avail <= 1 {
+				continue
+			}
+			text = ansi.Truncate(text, avail, "…")
+		}
+
+		// volné místo se dělí podle pos, zbytek po zaokrouhlení jde doleva
+		place := func(free int) int {
+			return free - int(float64(free)*(1-min(max(float64(l.pos), 0), 1)))
+		}
+
+		width := ansi.StringWidth(text) + delims
+		gap := inner - width
+		start := place(gap)
+		if gap >= 2 {
+			start = 1 + place(gap-2)
+		}
+		end := start + width
+
+		if slices.ContainsFunc(spans, func(sp span) bool { return start <= sp.end && sp.start <= end }) {
+			continue
+		}
+
+		spans = append(spans, span{start, end,
+			m.borderStyle.Render(m.titleOpen) + l.style.Render(text) + m.borderStyle.Render(m.titleClose)})
+	}
+	slices.SortFunc(spans, func(a, b span) int { return a.start - b.start })
+
+	s := m.borderStyle.Render(left)
+	at := 0
+	for _, sp := range spans {
+		s += m.borderStyle.Render(strings.Repeat(line, sp.start-at)) + sp.s
+		at = sp.end
+	}
+	s += m.borderStyle.Render(strings.Repeat(line, inner-at) + right)
 
 	return s
 }
@@ -199,6 +698,120 @@ func (m WindowModel) GetContent() string {
 func (m WindowModel) SetSize(width, height int) WindowModel {
 	m.width, m.height = width, height
 
+	return m.ViewScroll(0)
+}
+
+// ViewScroll() posune pohled o num řádků dolů/nahoru
+// Pokud je num < 0, posouvá pohled nahoru o num řádků
+// Pokud je num > 0, posouvá pohled dolů o num řádků
+// Pohled se neposune za začátek ani konec obsahu
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) ViewScroll(num int) WindowModel {
+	maxTop := max(len(m.contentLines())-m.innerHeight(), 0)
+	m.scrollTop = max(min(m.scrollTop+num, maxTop), 0)
+
+	return m
+}
+
+// PageScroll() posune pohled o num stránek (výšek obsahu okna) dolů/nahoru,
+// viz ViewScroll()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) PageScroll(num int) WindowModel {
+	return m.ViewScroll(num * max(m.innerHeight(), 1))
+}
+
+// ScrollToTop() posune pohled na začátek obsahu
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) ScrollToTop() WindowModel {
+	m.scrollTop = 0
+
+	return m
+}
+
+// ScrollToBottom() posune pohled na konec obsahu
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) ScrollToBottom() WindowModel {
+	m.scrollTop = max(len(m.contentLines())-m.innerHeight(), 0)
+
+	return m
+}
+
+// SetFooter() nastaví patičku okna, pokud je nastaveno na "" tak se nezobrazuje vůbec
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetFooter(footer string) WindowModel {
+	m.footer = footer
+
+	return m
+}
+
+// Focus() nastaví oknu focus, okno pak má okraj a titulek podle
+// WithFocusedBorderColors() a WithFocusedTitleColors()
+// Focus je jen vzhled, klávesy je potřeba do okna posílat z hlavního modelu
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) Focus() WindowModel {
+	m.focused = true
+
+	return m
+}
+
+// Blur() zruší focus okna, okno pak má okraj a titulek podle
+// WithBlurredBorderColors() a WithBlurredTitleColors()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) Blur() WindowModel {
+	m.focused = false
+
+	return m
+}
+
+// IsFocused() vrátí true, pokud má okno focus, viz Focus()
+func (m WindowModel) IsFocused() bool {
+	return m.focused
+}
+
+// SetBorderType() nastaví typ okraje okna, viz WithBorderType()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetBorderType(border lipgloss.Border) WindowModel {
+	m.borderType = border
+
+	return m
+}
+
+// SetBorderColors() nastaví barvy okraje, viz WithBorderColors()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetBorderColors(fg, bg lipgloss.Color) WindowModel {
+	m.borderStyle = lipgloss.NewStyle().
+		Foreground(fg).Background(bg).
+		Bold(true)
+
+	return m
+}
+
+// SetTitleColors() nastaví barvu titulku okna, viz WithTitleColors()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetTitleColors(fg, bg lipgloss.Color) WindowModel {
+	m.titleStyle = lipgloss.NewStyle().
+		Foreground(fg).Background(bg).
+		Bold(true)
+
+	return m
+}
+
+// SetFooterColors() nastaví barvu patičky okna, viz WithFooterColors()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetFooterColors(fg, bg lipgloss.Color) WindowModel {
+	m.footerStyle = lipgloss.NewStyle().
+		Foreground(fg).Background(bg)
+
+	return m
+}
+
+// SetContentColors() nastaví barvy obsahu okna, viz WithContentColors()
+// Vrací WindowModel, který je potřeba přiřadit/přepsat v hlavním modelu
+func (m WindowModel) SetContentColors(fg, bg lipgloss.Color) WindowModel {
+	m.contentStyle = lipgloss.NewStyle().
+		Foreground(fg).Background(bg).
+		Bold(true)
+
 	return m
 }
 
@@ -0,0 +1,89 @@
+package window
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func key(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestScrollTinyHeight(t *testing.T) {
+	content := strings.Repeat("řádek\n", 20)
+
+	for height := range 4 {
+		m := NewWindowModel(WithContent(content)).SetSize(10, height)
+
+		m = m.ScrollToBottom()
+		_ = m.View()
+
+		m, msg := m.Update(key("G"))
+		if height <= 2 && msg == nil {
+			t.Errorf("výška %d: klávesa se nemá při nulové výšce obsahu zpracovat", height)
+		}
+		_ = m.View()
+
+		m = m.PageScroll(3).ViewScroll(5)
+		_ = m.View()
+	}
+}
+
+func TestScrollAutoSizeBeforeResize(t *testing.T) {
+	m := NewWindowModel(WithAutoSize(true), WithContent(strings.Repeat("x\n", 10)))
+
+	for _, k := range []string{"G", "j", "k", "g"} {
+		m, _ = m.Update(key(k))
+		_ = m.View()
+	}
+
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 20, Height: 5})
+	m, _ = m.Update(key("G"))
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("počet řádků = %d, chci 5", len(lines))
+	}
+}
+
+func TestBorderLabelTruncation(t *testing.T) {
+	for width := 3; width <= 30; width++ {
+		m := NewWindowModel(
+			WithTitle("Dlouhý titulek okna"),
+			WithFooter("q konec · ? nápověda"),
+		).SetSize(width, 4)
+
+		lines := strings.Split(ansi.Strip(m.View()), "\n")
+		for _, line := range []string{lines[0], lines[len(lines)-1]} {
+			if strings.Contains(line, "...") {
+				t.Fatalf("šířka %d: text je zkrácený třemi tečkami: %q", width, line)
+			}
+			if got := ansi.StringWidth(line); got != width {
+				t.Fatalf("šířka %d: okraj má šířku %d: %q", width, got, line)
+			}
+		}
+	}
+}
+
+func TestPlaceOverlay(t *testing.T) {
+	bg := "\x1b[31maaaaaa\x1b[0m\nbbbbbb\ncccccc"
+
+	x, y := Place(6, 3, 2, 1, lipgloss.Center, lipgloss.Bottom)
+	if x != 2 || y != 2 {
+		t.Fatalf("Place() = %d, %d, chci 2, 2", x, y)
+	}
+	if x, y := Place(2, 2, 5, 5, lipgloss.Right, lipgloss.Bottom); x != 0 || y != 0 {
+		t.Fatalf("větší okno: Place() = %d, %d, chci 0, 0", x, y)
+	}
+
+	got := Overlay(bg, "XY\nZW", 4, 2)
+	if want := "aaaaaa\nbbbbbb\nccccXY"; ansi.Strip(got) != want {
+		t.Fatalf("Overlay() = %q, chci %q", ansi.Strip(got), want)
+	}
+	if !strings.HasPrefix(got, "\x1b[31m") {
+		t.Fatalf("Overlay() ztratil styl pozadí: %q", got)
+	}
+}